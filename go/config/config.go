@@ -12,6 +12,9 @@ import (
 	"github.com/ryansiau/KeepUpdated/go/notification"
 	"github.com/ryansiau/KeepUpdated/go/pkg/database"
 	"github.com/ryansiau/KeepUpdated/go/source"
+	"github.com/ryansiau/KeepUpdated/go/source/github"
+	"github.com/ryansiau/KeepUpdated/go/source/reddit"
+	"github.com/ryansiau/KeepUpdated/go/source/twitter"
 	"github.com/ryansiau/KeepUpdated/go/source/youtube"
 )
 
@@ -20,6 +23,15 @@ type Config struct {
 	Defaults  DefaultConfigs  `yaml:"defaults"`
 	Workflows []Workflow      `yaml:"workflows"`
 	Database  database.Config `yaml:"database"`
+	Server    ServerConfig    `yaml:"server"`
+}
+
+// ServerConfig configures the HTTP server receiving pushed updates, e.g. from WebSub hubs
+type ServerConfig struct {
+	// Listen is the address to listen on, e.g. ":8080". the server only starts when it's set.
+	Listen string `yaml:"listen"`
+	// PublicURL is where the server is reachable from the internet, the hubs call back under it
+	PublicURL string `yaml:"public_url"`
 }
 
 type DefaultConfigs struct {
@@ -29,7 +41,11 @@ type DefaultConfigs struct {
 }
 
 type DefaultCreds struct {
-	YoutubeAPIKey string `yaml:"youtube_api_key"`
+	YoutubeAPIKey      string `yaml:"youtube_api_key"`
+	RedditClientID     string `yaml:"reddit_client_id"`
+	RedditClientSecret string `yaml:"reddit_client_secret"`
+	TwitterBearerToken string `yaml:"twitter_bearer_token"`
+	GithubToken        string `yaml:"github_token"`
 }
 
 type Workflow struct {
@@ -39,6 +55,8 @@ type Workflow struct {
 	Source    source.BaseConfig         `yaml:"source"`
 	Filters   []filter.BaseConfig       `yaml:"filters"`
 	Notifiers []notification.BaseConfig `yaml:"notifiers"`
+	// WebSub subscribes to the hub of the source's feed, polling only while the hub doesn't push
+	WebSub bool `yaml:"websub"`
 }
 
 // LoadConfig loads configuration from a YAML file
@@ -92,6 +110,13 @@ func (c *Config) Validate() error {
 		if err := w.Validate(); err != nil {
 			return err
 		}
+
+		if w.WebSub && (c.Server.Listen == "" || c.Server.PublicURL == "") {
+			return fmt.Errorf("workflow %s: websub requires server.listen and server.public_url", w.Name)
+		}
+		if w.Source.Type == "webhook" && c.Server.Listen == "" {
+			return fmt.Errorf("workflow %s: webhook sources require server.listen", w.Name)
+		}
 	}
 
 	return nil
@@ -160,6 +185,22 @@ func (c *Config) applyDefaults() {
 			if sourceConfig.APIKey == "" && c.Defaults.Credentials.YoutubeAPIKey != "" {
 				sourceConfig.APIKey = c.Defaults.Credentials.YoutubeAPIKey
 			}
+		case "reddit":
+			sourceConfig := w.Source.Config.(*reddit.Config)
+			if sourceConfig.ClientID == "" && sourceConfig.ClientSecret == "" {
+				sourceConfig.ClientID = c.Defaults.Credentials.RedditClientID
+				sourceConfig.ClientSecret = c.Defaults.Credentials.RedditClientSecret
+			}
+		case "twitter":
+			sourceConfig := w.Source.Config.(*twitter.Config)
+			if sourceConfig.BearerToken == "" && c.Defaults.Credentials.TwitterBearerToken != "" {
+				sourceConfig.BearerToken = c.Defaults.Credentials.TwitterBearerToken
+			}
+		case "github":
+			sourceConfig := w.Source.Config.(*github.Config)
+			if sourceConfig.Token == "" && c.Defaults.Credentials.GithubToken != "" {
+				sourceConfig.Token = c.Defaults.Credentials.GithubToken
+			}
 		}
 
 		// replace workflows[].notifiers where type == "default" with defaults.notifiers
@@ -26,18 +26,24 @@ const (
 	// for string
 	CompContains    = "contains"
 	CompNotContains = "not_contains"
+
+	// for numbers
+	CompGreaterThan = "greater_than"
+	CompLessThan    = "less_than"
 )
 
 func validateComp(comp string) bool {
 	return comp == CompEqual ||
 		comp == CompNotEqual ||
 		comp == CompContains ||
-		comp == CompNotContains
+		comp == CompNotContains ||
+		comp == CompGreaterThan ||
+		comp == CompLessThan
 }
 
 func (c *Config) Validate() error {
 	for _, filter := range c.Conditions {
-		if validateComp(filter.Comp) {
+		if !validateComp(filter.Comp) {
 			return fmt.Errorf("comp value is unknown or unsupported")
 		}
 		if filter.Field == "" {
@@ -53,6 +59,12 @@ func (c *Config) Validate() error {
 				return fmt.Errorf("value has to be a string for the selected comp")
 			}
 		}
+
+		if filter.Comp == CompGreaterThan || filter.Comp == CompLessThan {
+			if _, ok := toFloat(filter.Value); !ok {
+				return fmt.Errorf("value has to be a number for the selected comp")
+			}
+		}
 	}
 
 	return nil
@@ -103,6 +115,18 @@ func (m *Metadata) Apply(content model.Content) bool {
 			if strings.Contains(val, cond.Value.(string)) {
 				return false
 			}
+		case CompGreaterThan, CompLessThan:
+			val, ok := toFloat(content.Metadata[cond.Field])
+			if !ok {
+				return false
+			}
+			threshold, _ := toFloat(cond.Value)
+			if cond.Comp == CompGreaterThan && !(val > threshold) {
+				return false
+			}
+			if cond.Comp == CompLessThan && !(val < threshold) {
+				return false
+			}
 		default:
 			return false
 		}
@@ -113,3 +137,21 @@ func (m *Metadata) Apply(content model.Content) bool {
 func (m *Metadata) Type() string {
 	return "Metadata"
 }
+
+// toFloat converts the numeric types found in metadata and YAML values into a float64
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case float32:
+		return float64(n), true
+	case float64:
+		return n, true
+	default:
+		return 0, false
+	}
+}
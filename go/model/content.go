@@ -23,6 +23,16 @@ type Content struct {
 
 type Metadata map[string]interface{}
 
+// MetadataThumbnail is the metadata key of an image URL representing the content,
+// e.g. the artwork of a podcast episode. notifiers show it when they can.
+const MetadataThumbnail = "thumbnail"
+
+// Thumbnail returns the image URL stored under MetadataThumbnail, empty when there is none
+func (c Content) Thumbnail() string {
+	thumbnail, _ := c.Metadata[MetadataThumbnail].(string)
+	return thumbnail
+}
+
 func (m Metadata) Value() (driver.Value, error) {
 	if m == nil {
 		return nil, nil
@@ -1,6 +1,10 @@
 package model
 
-import "context"
+import (
+	"context"
+	"errors"
+	"net/http"
+)
 
 // Source defines the interface for content sources
 type Source interface {
@@ -17,3 +21,63 @@ type Source interface {
 	// this should be unique for each source
 	SourceID() string
 }
+
+// StatefulSource is implemented by sources that keep a cursor between executions,
+// e.g. the id of the latest post or an ETag.
+// The worker loads the state stored for the source before calling Fetch,
+// and stores the state returned by State once the fetched contents have been processed.
+type StatefulSource interface {
+	Source
+
+	// LoadState restores the state stored by a previous execution
+	LoadState(state map[string]string)
+
+	// State returns the state to be stored for the next execution
+	State() map[string]string
+}
+
+// AcknowledgingSource is implemented by sources that tell their origin once the fetched contents have been processed,
+// e.g. marking the messages of a mailbox as read.
+// The worker calls Acknowledge with every fetched content once they're stored, after the state is.
+type AcknowledgingSource interface {
+	Source
+
+	// Acknowledge tells the origin that the contents, fetched by the last call to Fetch, have been processed
+	Acknowledge(ctx context.Context, contents []Content) error
+}
+
+// WatchingSource is implemented by sources that can tell when they may have new contents, e.g. through filesystem events.
+// The worker executes the workflow as soon as changed is called, and keeps polling it on its interval in case an event got lost.
+type WatchingSource interface {
+	Source
+
+	// Watch starts watching in the background until ctx is done, calling changed on every change.
+	// it returns an error when watching isn't possible, the source is then only polled.
+	Watch(ctx context.Context, changed func()) error
+}
+
+// PushSource is implemented by sources whose feed can be pushed by a WebSub hub.
+// The worker subscribes to the hub advertised by the topic, and processes pushed feeds like fetched ones.
+type PushSource interface {
+	Source
+
+	// Topic returns the URL of the feed to subscribe to, and its hub when it's known upfront.
+	// an empty hub is discovered from the feed.
+	Topic(ctx context.Context) (topic string, hub string, err error)
+
+	// ParsePush converts a pushed feed into contents, the same way Fetch would
+	ParsePush(ctx context.Context, body []byte) ([]Content, error)
+}
+
+// InboundSource is implemented by sources that receive their contents over HTTP instead of fetching them.
+// The worker doesn't poll them, it processes the contents of every request to /hooks/<workflow> right away.
+type InboundSource interface {
+	Source
+
+	// Receive authenticates the request and converts its body into contents.
+	// it returns an error wrapping ErrUnauthorized when the request isn't authenticated.
+	Receive(ctx context.Context, header http.Header, body []byte) ([]Content, error)
+}
+
+// ErrUnauthorized is returned by InboundSource when a request fails its authentication
+var ErrUnauthorized = errors.New("unauthorized")
@@ -0,0 +1,11 @@
+package model
+
+import "time"
+
+// SourceState is a key-value pair stored for a source between executions
+type SourceState struct {
+	SourceID  string `gorm:"primaryKey"`
+	Key       string `gorm:"primaryKey"`
+	Value     string
+	UpdatedAt time.Time
+}
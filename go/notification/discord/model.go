@@ -17,6 +17,7 @@ type Embed struct {
 	Footer      EmbedFooter  `json:"footer,omitempty"`
 	Timestamp   time.Time    `json:"timestamp,omitempty"`
 	Image       EmbedImage   `json:"image,omitempty"`
+	Thumbnail   *EmbedImage  `json:"thumbnail,omitempty"`
 	Fields      []EmbedField `json:"fields,omitempty"`
 }
 
@@ -74,6 +74,10 @@ func (d *DiscordWebhook) Send(ctx context.Context, content model.Content) error
 		Attachments: nil,
 	}
 
+	if thumbnail := content.Thumbnail(); thumbnail != "" {
+		payload.Embeds[0].Thumbnail = &EmbedImage{Url: thumbnail}
+	}
+
 	req := d.client.R().SetContext(ctx)
 
 	req.SetHeader("Content-Type", "application/json")
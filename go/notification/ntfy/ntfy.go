@@ -66,6 +66,9 @@ func (n *Ntfy) Send(ctx context.Context, content model.Content) error {
 	req.SetHeader("X-Title", fmt.Sprintf("New update from %s (%s)", content.Author, content.Platform))
 	req.SetHeader("X-Priority", n.conf.Priority)
 	req.SetHeader("X-Click", content.URL)
+	if thumbnail := content.Thumbnail(); thumbnail != "" {
+		req.SetHeader("X-Icon", thumbnail)
+	}
 
 	finalURL, err := url.JoinPath(n.conf.BaseURL, n.conf.Topic)
 	if err != nil {
@@ -44,6 +44,7 @@ func NewDB(conf *Config) (*gorm.DB, error) {
 func Migrate(db *gorm.DB) error {
 	err := db.AutoMigrate(
 		&model.Content{},
+		&model.SourceState{},
 		&ConnectionTest{},
 	)
 	if err != nil {
@@ -0,0 +1,47 @@
+package database
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/ryansiau/KeepUpdated/go/model"
+)
+
+// LoadSourceState returns the state stored for the source
+func LoadSourceState(db *gorm.DB, sourceID string) (map[string]string, error) {
+	var rows []model.SourceState
+	res := db.Where("source_id = ?", sourceID).Find(&rows)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+
+	state := make(map[string]string, len(rows))
+	for _, row := range rows {
+		state[row.Key] = row.Value
+	}
+
+	return state, nil
+}
+
+// SaveSourceState upserts the state of the source. keys missing from state are left untouched.
+func SaveSourceState(db *gorm.DB, sourceID string, state map[string]string) error {
+	if len(state) == 0 {
+		return nil
+	}
+
+	rows := make([]model.SourceState, 0, len(state))
+	for key, value := range state {
+		rows = append(rows, model.SourceState{
+			SourceID: sourceID,
+			Key:      key,
+			Value:    value,
+		})
+	}
+
+	res := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "source_id"}, {Name: "key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"value", "updated_at"}),
+	}).Create(&rows)
+
+	return res.Error
+}
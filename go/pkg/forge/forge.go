@@ -0,0 +1,88 @@
+// Package forge converts the releases and tags of the forges, e.g. GitHub, GitLab and Gitea, into contents.
+// the forges only differ in their APIs, the sources fetch and fill these types.
+package forge
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ryansiau/KeepUpdated/go/model"
+)
+
+// Release is a published release of a repository
+type Release struct {
+	// Repository is the full path of the repository, e.g. owner/repo
+	Repository  string
+	TagName     string
+	Name        string
+	Body        string
+	URL         string
+	Author      string
+	Prerelease  bool
+	PublishedAt time.Time
+}
+
+// Tag is a tag of a repository
+type Tag struct {
+	// Repository is the full path of the repository, e.g. owner/repo
+	Repository string
+	Name       string
+	Message    string
+	URL        string
+	Author     string
+	CommitSHA  string
+	// PublishedAt is the date of the tagged commit, now when the forge doesn't tell
+	PublishedAt time.Time
+}
+
+// Content converts the release into a content with the given id
+func (r Release) Content(id, sourceID, platform string) model.Content {
+	title := r.Name
+	if title == "" {
+		title = r.TagName
+	}
+
+	return model.Content{
+		ID:          id,
+		SourceID:    sourceID,
+		Title:       fmt.Sprintf("%s %s", r.Repository, title),
+		Description: r.Body,
+		URL:         r.URL,
+		Author:      r.Author,
+		Platform:    platform,
+		PublishedAt: r.PublishedAt,
+		UpdatedAt:   time.Now(),
+		Metadata: map[string]interface{}{
+			"repository": r.Repository,
+			"tag_name":   r.TagName,
+			"name":       r.Name,
+			"prerelease": r.Prerelease,
+			"body":       r.Body,
+		},
+	}
+}
+
+// Content converts the tag into a content with the given id
+func (t Tag) Content(id, sourceID, platform string) model.Content {
+	publishedAt := t.PublishedAt
+	if publishedAt.IsZero() {
+		publishedAt = time.Now()
+	}
+
+	return model.Content{
+		ID:          id,
+		SourceID:    sourceID,
+		Title:       fmt.Sprintf("%s %s", t.Repository, t.Name),
+		Description: t.Message,
+		URL:         t.URL,
+		Author:      t.Author,
+		Platform:    platform,
+		PublishedAt: publishedAt,
+		UpdatedAt:   time.Now(),
+		Metadata: map[string]interface{}{
+			"repository": t.Repository,
+			"tag_name":   t.Name,
+			"commit_sha": t.CommitSHA,
+		},
+	}
+}
@@ -0,0 +1,65 @@
+// Package htmltext converts HTML fragments, such as post bodies, into plain text for notifications.
+package htmltext
+
+import (
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+var (
+	spacesRegex   = regexp.MustCompile(`[ \t\r\f\v]+`)
+	newlinesRegex = regexp.MustCompile(`\n{3,}`)
+)
+
+// ToText strips the tags of s, keeping line breaks between paragraphs and skipping scripts and styles
+func ToText(s string) string {
+	var sb strings.Builder
+	skip := 0
+
+	tokenizer := html.NewTokenizer(strings.NewReader(s))
+	for {
+		tokenType := tokenizer.Next()
+		if tokenType == html.ErrorToken {
+			break
+		}
+
+		token := tokenizer.Token()
+		switch tokenType {
+		case html.TextToken:
+			if skip == 0 {
+				sb.WriteString(strings.ReplaceAll(token.Data, "\n", " "))
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			switch token.Data {
+			case "script", "style":
+				if tokenType == html.StartTagToken {
+					skip++
+				}
+			case "br":
+				sb.WriteString("\n")
+			case "li":
+				sb.WriteString("\n- ")
+			case "p", "div", "blockquote", "pre", "ul", "ol", "h1", "h2", "h3", "h4", "h5", "h6", "tr":
+				sb.WriteString("\n\n")
+			}
+		case html.EndTagToken:
+			switch token.Data {
+			case "script", "style":
+				if skip > 0 {
+					skip--
+				}
+			case "p", "div", "blockquote", "pre", "ul", "ol", "h1", "h2", "h3", "h4", "h5", "h6", "tr":
+				sb.WriteString("\n\n")
+			}
+		}
+	}
+
+	lines := strings.Split(sb.String(), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(spacesRegex.ReplaceAllString(line, " "))
+	}
+
+	return strings.TrimSpace(newlinesRegex.ReplaceAllString(strings.Join(lines, "\n"), "\n\n"))
+}
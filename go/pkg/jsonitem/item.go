@@ -0,0 +1,64 @@
+// Package jsonitem is the JSON format of the contents written by scripts, e.g. printed by a command or saved to a file.
+package jsonitem
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ryansiau/KeepUpdated/go/model"
+	generic_rss "github.com/ryansiau/KeepUpdated/go/source/generic-rss"
+)
+
+// Item is a content written by a script, as a JSON object
+type Item struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	URL         string `json:"url"`
+	Author      string `json:"author"`
+	Description string `json:"description"`
+	// PublishedAt is either a date string, e.g. RFC 3339, or a unix timestamp in seconds
+	PublishedAt interface{}            `json:"published_at"`
+	Metadata    map[string]interface{} `json:"metadata"`
+}
+
+// Content converts the item into a content of the source, its id is namespaced by the source id
+func (i Item) Content(sourceID, platform string) (model.Content, error) {
+	if i.ID == "" {
+		return model.Content{}, fmt.Errorf("id is required")
+	}
+
+	publishedAt, err := parsePublishedAt(i.PublishedAt)
+	if err != nil {
+		return model.Content{}, err
+	}
+
+	return model.Content{
+		ID:          sourceID + ":" + i.ID,
+		SourceID:    sourceID,
+		Title:       i.Title,
+		Description: i.Description,
+		URL:         i.URL,
+		Author:      i.Author,
+		Platform:    platform,
+		PublishedAt: publishedAt,
+		UpdatedAt:   time.Now(),
+		Metadata:    i.Metadata,
+	}, nil
+}
+
+// parsePublishedAt parses a date string or a unix timestamp, a missing date is now
+func parsePublishedAt(value interface{}) (time.Time, error) {
+	switch v := value.(type) {
+	case nil:
+		return time.Now(), nil
+	case float64:
+		return time.Unix(int64(v), 0), nil
+	case string:
+		if v == "" {
+			return time.Now(), nil
+		}
+		return generic_rss.ParseDate(v)
+	default:
+		return time.Time{}, fmt.Errorf("invalid published_at: %v", value)
+	}
+}
@@ -0,0 +1,80 @@
+package websub
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"resty.dev/v3"
+)
+
+var linkHeaderRegex = regexp.MustCompile(`<([^>]+)>\s*;\s*rel="?([^";]+)"?`)
+
+// feedLinks reads the <link> and <atom:link> elements of RSS and Atom feeds
+type feedLinks struct {
+	Links   []feedLink `xml:"link"`
+	Channel struct {
+		Links []feedLink `xml:"link"`
+	} `xml:"channel"`
+}
+
+type feedLink struct {
+	Rel  string `xml:"rel,attr"`
+	Href string `xml:"href,attr"`
+}
+
+// Discover finds the hub advertised by a feed, in its Link headers or its rel="hub" links.
+// topic is the self URL of the feed, feedURL when the feed doesn't tell.
+func Discover(ctx context.Context, client *resty.Client, feedURL string) (hub string, topic string, err error) {
+	resp, err := client.R().
+		SetContext(ctx).
+		SetDoNotParseResponse(true).
+		Get(feedURL)
+	if err != nil {
+		return "", "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode() != 200 {
+		return "", "", fmt.Errorf("status code: %d", resp.StatusCode())
+	}
+
+	links := map[string]string{}
+	for _, header := range resp.Header().Values("Link") {
+		for _, match := range linkHeaderRegex.FindAllStringSubmatch(header, -1) {
+			for _, rel := range strings.Fields(match[2]) {
+				if _, ok := links[rel]; !ok {
+					links[rel] = match[1]
+				}
+			}
+		}
+	}
+
+	var feed feedLinks
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", "", err
+	}
+	if err := xml.Unmarshal(body, &feed); err == nil {
+		for _, link := range append(feed.Links, feed.Channel.Links...) {
+			if _, ok := links[link.Rel]; !ok && link.Href != "" {
+				links[link.Rel] = strings.TrimSpace(link.Href)
+			}
+		}
+	}
+
+	hub = links["hub"]
+	if hub == "" {
+		return "", "", fmt.Errorf("feed doesn't advertise a hub")
+	}
+
+	topic = links["self"]
+	if topic == "" {
+		topic = feedURL
+	}
+
+	return hub, topic, nil
+}
@@ -0,0 +1,343 @@
+// Package websub subscribes to WebSub (PubSubHubbub) hubs and receives the content they push.
+package websub
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"resty.dev/v3"
+
+	"github.com/ryansiau/KeepUpdated/go/common"
+)
+
+const (
+	// PathPrefix is the path of the callbacks, followed by the id of the subscription
+	PathPrefix = "/websub/"
+
+	// leaseSeconds is the lease requested from the hubs, which may grant another one
+	leaseSeconds = 10 * 24 * 60 * 60
+
+	// retryDelay is how long a failed subscription waits before the next attempt
+	retryDelay = 30 * time.Minute
+
+	// maxPushSize caps the body of a push
+	maxPushSize = 10 << 20
+)
+
+// TopicFunc returns the URL of the feed to subscribe to, and its hub when it's known upfront
+type TopicFunc func(ctx context.Context) (topic string, hub string, err error)
+
+// PushFunc handles the feed pushed by the hub
+type PushFunc func(ctx context.Context, body []byte) error
+
+type subscription struct {
+	id     string
+	topic  TopicFunc
+	handle PushFunc
+
+	hubURL   string
+	topicURL string
+	// secret signs the pushes since the hub verified the subscription, previousSecret the ones sent
+	// before the last verification. pendingSecret is the one of the last request, until the hub verifies it.
+	secret         string
+	previousSecret string
+	pendingSecret  string
+
+	// verified is set once the hub confirmed the subscription, until expiresAt
+	verified  bool
+	expiresAt time.Time
+	// lease is the duration granted by the hub on the last verification
+	lease time.Duration
+	// retryAt delays the next attempt after a failure
+	retryAt time.Time
+}
+
+// Manager keeps the subscriptions alive, and serves their callbacks
+type Manager struct {
+	client    *resty.Client
+	publicURL string
+
+	mu            sync.Mutex
+	subscriptions map[string]*subscription
+}
+
+// NewManager creates a manager whose callbacks are reachable under publicURL
+func NewManager(publicURL string) *Manager {
+	return &Manager{
+		client: resty.New().
+			SetTimeout(30*time.Second).
+			SetHeader("User-Agent", common.HTTPClientUserAgent),
+		publicURL:     strings.TrimSuffix(publicURL, "/"),
+		subscriptions: map[string]*subscription{},
+	}
+}
+
+// Add registers a subscription, the next renewal round subscribes to it
+func (m *Manager) Add(id string, topic TopicFunc, handle PushFunc) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.subscriptions[id] = &subscription{
+		id:     id,
+		topic:  topic,
+		handle: handle,
+	}
+}
+
+// Active tells whether the hub currently pushes the updates of the subscription.
+// polling takes over whenever it doesn't.
+func (m *Manager) Active(id string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	sub, ok := m.subscriptions[id]
+	return ok && sub.verified && time.Now().Before(sub.expiresAt)
+}
+
+// Run subscribes and renews the leases before they expire, until ctx is done
+func (m *Manager) Run(ctx context.Context) {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for {
+		m.renew(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// renew subscribes to the subscriptions that are new, failed, or expire soon
+func (m *Manager) renew(ctx context.Context) {
+	m.mu.Lock()
+	var due []*subscription
+	for _, sub := range m.subscriptions {
+		if time.Now().Before(sub.retryAt) {
+			continue
+		}
+		if sub.verified && time.Until(sub.expiresAt) > renewBefore(sub) {
+			continue
+		}
+		due = append(due, sub)
+	}
+	m.mu.Unlock()
+
+	for _, sub := range due {
+		if err := m.subscribe(ctx, sub); err != nil {
+			logrus.WithField("subscription", sub.id).Warnf("WebSub subscription failed, polling instead: %v", err)
+
+			m.mu.Lock()
+			sub.retryAt = time.Now().Add(retryDelay)
+			m.mu.Unlock()
+		}
+	}
+}
+
+// renewBefore leaves a tenth of the granted lease, and at least an hour, to renew it
+func renewBefore(sub *subscription) time.Duration {
+	return max(sub.lease/10, time.Hour)
+}
+
+// subscribe discovers the hub of the topic and asks it for a subscription.
+// the hub confirms it asynchronously through the callback.
+func (m *Manager) subscribe(ctx context.Context, sub *subscription) error {
+	topicURL, hubURL, err := sub.topic(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get topic: %w", err)
+	}
+
+	if hubURL == "" {
+		feedURL := topicURL
+		hubURL, topicURL, err = Discover(ctx, m.client, feedURL)
+		if err != nil {
+			return fmt.Errorf("failed to discover hub of %s: %w", feedURL, err)
+		}
+	}
+
+	secret, err := newSecret()
+	if err != nil {
+		return err
+	}
+
+	// the hub keeps signing with the verified secret until it verifies this request, which may never happen
+	m.mu.Lock()
+	sub.hubURL, sub.topicURL = hubURL, topicURL
+	sub.pendingSecret = secret
+	m.mu.Unlock()
+
+	resp, err := m.client.R().
+		SetContext(ctx).
+		SetFormData(map[string]string{
+			"hub.mode":          "subscribe",
+			"hub.topic":         topicURL,
+			"hub.callback":      m.publicURL + PathPrefix + url.PathEscape(sub.id),
+			"hub.secret":        secret,
+			"hub.lease_seconds": strconv.Itoa(leaseSeconds),
+		}).
+		Post(hubURL)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode() < 200 || resp.StatusCode() > 299 {
+		return fmt.Errorf("hub %s answered with status code: %d, body: %s", hubURL, resp.StatusCode(), resp.String())
+	}
+
+	// the request is only retried if the hub doesn't verify it in the meantime
+	m.mu.Lock()
+	sub.retryAt = time.Now().Add(retryDelay)
+	m.mu.Unlock()
+
+	logrus.WithField("subscription", sub.id).Infof("Requested WebSub subscription to %s on %s", topicURL, hubURL)
+	return nil
+}
+
+func newSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// ServeHTTP answers the verification requests of the hubs (GET) and receives their pushes (POST)
+func (m *Manager) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimPrefix(r.URL.Path, PathPrefix)
+
+	m.mu.Lock()
+	sub, ok := m.subscriptions[id]
+	m.mu.Unlock()
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		m.verify(w, r, sub)
+	case http.MethodPost:
+		m.receive(w, r, sub)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// verify echoes the challenge of the subscription the manager asked for
+func (m *Manager) verify(w http.ResponseWriter, r *http.Request, sub *subscription) {
+	query := r.URL.Query()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	switch query.Get("hub.mode") {
+	case "subscribe":
+		if query.Get("hub.topic") != sub.topicURL || sub.topicURL == "" {
+			http.NotFound(w, r)
+			return
+		}
+
+		lease, err := strconv.Atoi(query.Get("hub.lease_seconds"))
+		if err != nil || lease <= 0 {
+			lease = leaseSeconds
+		}
+
+		if sub.pendingSecret != "" {
+			sub.previousSecret, sub.secret = sub.secret, sub.pendingSecret
+			sub.pendingSecret = ""
+		}
+		sub.verified = true
+		sub.lease = time.Duration(lease) * time.Second
+		sub.expiresAt = time.Now().Add(sub.lease)
+		sub.retryAt = time.Time{}
+		logrus.WithField("subscription", sub.id).Infof("WebSub subscription verified until %s", sub.expiresAt.Format(time.RFC3339))
+
+		_, _ = io.WriteString(w, query.Get("hub.challenge"))
+	case "denied":
+		sub.verified = false
+		sub.retryAt = time.Now().Add(retryDelay)
+		logrus.WithField("subscription", sub.id).Warnf("WebSub subscription denied by the hub: %s", query.Get("hub.reason"))
+		w.WriteHeader(http.StatusOK)
+	default:
+		// the manager never unsubscribes, so any other request isn't ours
+		http.NotFound(w, r)
+	}
+}
+
+// receive checks the signature of the push and hands the feed over
+func (m *Manager) receive(w http.ResponseWriter, r *http.Request, sub *subscription) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxPushSize))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	m.mu.Lock()
+	secrets := []string{sub.secret, sub.previousSecret}
+	m.mu.Unlock()
+
+	// a push with a bad signature is acknowledged but ignored, as the spec asks
+	signature := r.Header.Get("X-Hub-Signature")
+	if !slices.ContainsFunc(secrets, func(secret string) bool { return validSignature(signature, secret, body) }) {
+		logrus.WithField("subscription", sub.id).Warn("Ignored WebSub push with an invalid signature")
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	if err := sub.handle(r.Context(), body); err != nil {
+		logrus.WithField("subscription", sub.id).Errorf("Failed to process WebSub push: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// validSignature checks the X-Hub-Signature header, e.g. sha256=<hex HMAC of the body>
+func validSignature(header, secret string, body []byte) bool {
+	method, signature, ok := strings.Cut(header, "=")
+	if !ok || secret == "" {
+		return false
+	}
+
+	var newHash func() hash.Hash
+	switch method {
+	case "sha1":
+		newHash = sha1.New
+	case "sha256":
+		newHash = sha256.New
+	case "sha384":
+		newHash = sha512.New384
+	case "sha512":
+		newHash = sha512.New
+	default:
+		return false
+	}
+
+	expected, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(newHash, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
@@ -0,0 +1,254 @@
+package websub
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const topicURL = "https://example.com/feed.xml"
+
+// hubStandIn records the subscription requests, and answers them with status
+type hubStandIn struct {
+	mu       sync.Mutex
+	status   int
+	requests []url.Values
+}
+
+func (h *hubStandIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.requests = append(h.requests, r.PostForm)
+	w.WriteHeader(h.status)
+}
+
+func (h *hubStandIn) setStatus(status int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.status = status
+}
+
+func (h *hubStandIn) requestCount() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return len(h.requests)
+}
+
+// lastRequest returns the last subscription request received by the hub
+func (h *hubStandIn) lastRequest(t *testing.T) url.Values {
+	t.Helper()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.requests) == 0 {
+		t.Fatal("the hub didn't receive any request")
+	}
+	return h.requests[len(h.requests)-1]
+}
+
+// newManager serves a manager with a "feed" subscription whose topic is served by the returned hub.
+// the pushes handed over are sent to the returned channel.
+func newManager(t *testing.T) (*Manager, *hubStandIn, *httptest.Server, chan []byte) {
+	t.Helper()
+
+	hub := &hubStandIn{status: http.StatusAccepted}
+	hubServer := httptest.NewServer(hub)
+	t.Cleanup(hubServer.Close)
+
+	var m *Manager
+	callbackServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		m.ServeHTTP(w, r)
+	}))
+	t.Cleanup(callbackServer.Close)
+
+	pushes := make(chan []byte, 10)
+	m = NewManager(callbackServer.URL)
+	m.Add("feed", func(ctx context.Context) (string, string, error) {
+		return topicURL, hubServer.URL, nil
+	}, func(ctx context.Context, body []byte) error {
+		pushes <- body
+		return nil
+	})
+
+	return m, hub, callbackServer, pushes
+}
+
+// verify sends the verification request of the hub, returning the status code and the body of the answer
+func verify(t *testing.T, callbackURL string, query url.Values) (int, string) {
+	t.Helper()
+
+	resp, err := http.Get(callbackURL + "?" + query.Encode())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(body)
+}
+
+// push sends body to the callback, signed with secret
+func push(t *testing.T, callbackURL string, newHash func() hash.Hash, method, secret, body string) {
+	t.Helper()
+
+	mac := hmac.New(newHash, []byte(secret))
+	mac.Write([]byte(body))
+
+	req, _ := http.NewRequest(http.MethodPost, callbackURL, strings.NewReader(body))
+	req.Header.Set("X-Hub-Signature", method+"="+hex.EncodeToString(mac.Sum(nil)))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusAccepted {
+		t.Errorf("push answered with %d, want 202 whether the signature is valid or not", resp.StatusCode)
+	}
+}
+
+// subscribeAndVerify requests the subscription and verifies it like the hub would, returning its secret
+func subscribeAndVerify(t *testing.T, m *Manager, hub *hubStandIn) string {
+	t.Helper()
+
+	if err := m.subscribe(context.Background(), m.subscriptions["feed"]); err != nil {
+		t.Fatal(err)
+	}
+	request := hub.lastRequest(t)
+
+	status, body := verify(t, request.Get("hub.callback"), url.Values{
+		"hub.mode":          {"subscribe"},
+		"hub.topic":         {topicURL},
+		"hub.challenge":     {"challenge"},
+		"hub.lease_seconds": {"3600"},
+	})
+	if status != http.StatusOK || body != "challenge" {
+		t.Fatalf("verification answered with %d %q, want the challenge", status, body)
+	}
+
+	return request.Get("hub.secret")
+}
+
+func TestVerify(t *testing.T) {
+	m, hub, _, _ := newManager(t)
+
+	m.renew(context.Background())
+	request := hub.lastRequest(t)
+	if request.Get("hub.mode") != "subscribe" || request.Get("hub.topic") != topicURL || request.Get("hub.secret") == "" {
+		t.Fatalf("unexpected subscription request: %v", request)
+	}
+	if m.Active("feed") {
+		t.Fatal("the subscription is active before the hub verified it")
+	}
+
+	// a verification of another topic isn't ours
+	status, _ := verify(t, request.Get("hub.callback"), url.Values{
+		"hub.mode":      {"subscribe"},
+		"hub.topic":     {"https://example.com/other.xml"},
+		"hub.challenge": {"challenge"},
+	})
+	if status != http.StatusNotFound || m.Active("feed") {
+		t.Errorf("verification of another topic answered with %d", status)
+	}
+
+	status, body := verify(t, request.Get("hub.callback"), url.Values{
+		"hub.mode":          {"subscribe"},
+		"hub.topic":         {topicURL},
+		"hub.challenge":     {"challenge"},
+		"hub.lease_seconds": {"86400"},
+	})
+	if status != http.StatusOK || body != "challenge" {
+		t.Errorf("verification answered with %d %q, want the challenge", status, body)
+	}
+	if !m.Active("feed") {
+		t.Error("the subscription isn't active once verified")
+	}
+	if lease := m.subscriptions["feed"].lease; lease != 24*time.Hour {
+		t.Errorf("lease = %s, want the one granted by the hub", lease)
+	}
+
+	// the lease is renewed a tenth of it, at least an hour, before it expires, so not yet
+	m.renew(context.Background())
+	if count := hub.requestCount(); count != 1 {
+		t.Errorf("the hub received %d requests, want the verified subscription to be left alone", count)
+	}
+}
+
+func TestReceiveSignature(t *testing.T) {
+	m, hub, callbackServer, pushes := newManager(t)
+	secret := subscribeAndVerify(t, m, hub)
+	callbackURL := callbackServer.URL + PathPrefix + "feed"
+
+	push(t, callbackURL, sha256.New, "sha256", secret, "signed")
+	push(t, callbackURL, sha1.New, "sha1", secret, "signed with sha1")
+	push(t, callbackURL, sha256.New, "sha256", "another secret", "forged")
+	push(t, callbackURL, sha256.New, "md5", secret, "unknown method")
+
+	close(pushes)
+	var received []string
+	for body := range pushes {
+		received = append(received, string(body))
+	}
+	if strings.Join(received, ",") != "signed,signed with sha1" {
+		t.Errorf("handed over %q, want only the pushes with a valid signature", received)
+	}
+}
+
+func TestSecretRotation(t *testing.T) {
+	m, hub, callbackServer, pushes := newManager(t)
+	verified := subscribeAndVerify(t, m, hub)
+	callbackURL := callbackServer.URL + PathPrefix + "feed"
+
+	// renewals the hub never verifies don't replace the secret it signs with
+	hub.setStatus(http.StatusInternalServerError)
+	for range 2 {
+		if err := m.subscribe(context.Background(), m.subscriptions["feed"]); err == nil {
+			t.Fatal("expected an error from the failing hub")
+		}
+	}
+	hub.setStatus(http.StatusAccepted)
+	if err := m.subscribe(context.Background(), m.subscriptions["feed"]); err != nil {
+		t.Fatal(err)
+	}
+	unverified := hub.lastRequest(t).Get("hub.secret")
+
+	push(t, callbackURL, sha256.New, "sha256", verified, "verified")
+	push(t, callbackURL, sha256.New, "sha256", unverified, "unverified")
+	if body := string(<-pushes); body != "verified" || len(pushes) != 0 {
+		t.Fatalf("handed over %q and %d more pushes, want the one signed with the verified secret", body, len(pushes))
+	}
+
+	// once verified, the new secret takes over while the previous one still signs the pushes in flight
+	renewed := subscribeAndVerify(t, m, hub)
+	push(t, callbackURL, sha256.New, "sha256", renewed, "renewed")
+	push(t, callbackURL, sha256.New, "sha256", verified, "in flight")
+	if first, second := string(<-pushes), string(<-pushes); first != "renewed" || second != "in flight" {
+		t.Errorf("handed over %q and %q", first, second)
+	}
+
+	subscribeAndVerify(t, m, hub)
+	push(t, callbackURL, sha256.New, "sha256", verified, "outdated")
+	if len(pushes) != 0 {
+		t.Errorf("handed over a push signed with a secret two renewals old")
+	}
+}
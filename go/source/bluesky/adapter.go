@@ -0,0 +1,258 @@
+package bluesky
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"resty.dev/v3"
+
+	"github.com/ryansiau/KeepUpdated/go/common"
+	"github.com/ryansiau/KeepUpdated/go/model"
+)
+
+// appViewURL is a variable so a local stand-in server can take its place
+var appViewURL = "https://public.api.bsky.app/xrpc"
+
+// feedLimit is the number of feed items requested on every fetch
+const feedLimit = 50
+
+// stateDID keeps the DID resolved from the handle
+const stateDID = "did"
+
+// Adapter implements the Source interface for Bluesky author feeds
+type Adapter struct {
+	client *resty.Client
+	config *Config
+	name   string
+
+	did string
+}
+
+var _ model.StatefulSource = (*Adapter)(nil)
+
+// NewAdapter creates a new Bluesky adapter with configuration
+func NewAdapter(config *Config, name string) (*Adapter, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	if name == "" {
+		name = "Bluesky: " + config.actor()
+	}
+
+	client := resty.New().
+		SetTimeout(30*time.Second).
+		SetHeader("User-Agent", common.HTTPClientUserAgent)
+
+	adapter := &Adapter{
+		client: client,
+		config: config,
+		name:   name,
+	}
+	if strings.HasPrefix(config.actor(), "did:") {
+		adapter.did = config.actor()
+	}
+
+	return adapter, nil
+}
+
+// Name returns the name of the source
+func (a *Adapter) Name() string {
+	return a.name
+}
+
+// Type returns the platform type
+func (a *Adapter) Type() string {
+	return "bluesky"
+}
+
+// SourceID returns the identifier of the source
+func (a *Adapter) SourceID() string {
+	return "Bluesky:" + a.config.actor()
+}
+
+// LoadState restores the DID resolved from the handle
+func (a *Adapter) LoadState(state map[string]string) {
+	if a.did == "" {
+		a.did = state[stateDID]
+	}
+}
+
+// State returns the resolved DID, to skip the resolution on the next execution
+func (a *Adapter) State() map[string]string {
+	if a.did == "" {
+		return nil
+	}
+	return map[string]string{stateDID: a.did}
+}
+
+// xrpc calls a query method of the AppView and decodes the JSON response into result
+func (a *Adapter) xrpc(ctx context.Context, method string, params map[string]string, result interface{}) error {
+	resp, err := a.client.R().
+		SetContext(ctx).
+		SetQueryParams(params).
+		SetResult(result).
+		SetForceResponseContentType("application/json").
+		Get(appViewURL + "/" + method)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode() != 200 {
+		return fmt.Errorf("%s status code: %d, body: %s", method, resp.StatusCode(), resp.String())
+	}
+
+	return nil
+}
+
+// ResolveHandle returns the DID of a handle
+func (a *Adapter) ResolveHandle(ctx context.Context, handle string) (string, error) {
+	var res struct {
+		DID string `json:"did"`
+	}
+
+	err := a.xrpc(ctx, "com.atproto.identity.resolveHandle", map[string]string{"handle": handle}, &res)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve handle %s: %w", handle, err)
+	}
+
+	if res.DID == "" {
+		return "", fmt.Errorf("handle %s not found", handle)
+	}
+
+	return res.DID, nil
+}
+
+// Fetch retrieves the latest posts of the author feed
+func (a *Adapter) Fetch(ctx context.Context) ([]model.Content, error) {
+	if a.did == "" {
+		did, err := a.ResolveHandle(ctx, a.config.actor())
+		if err != nil {
+			return nil, err
+		}
+		a.did = did
+	}
+
+	filter := "posts_with_replies"
+	if a.config.ExcludeReplies {
+		filter = "posts_no_replies"
+	}
+
+	var res struct {
+		Feed []FeedItem `json:"feed"`
+	}
+
+	err := a.xrpc(ctx, "app.bsky.feed.getAuthorFeed", map[string]string{
+		"actor":  a.did,
+		"limit":  fmt.Sprint(feedLimit),
+		"filter": filter,
+	}, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	var contents []model.Content
+	seen := map[string]struct{}{}
+	for _, item := range res.Feed {
+		isRepost := item.Reason != nil && strings.HasSuffix(item.Reason.Type, "#reasonRepost")
+		if isRepost && a.config.ExcludeReposts {
+			continue
+		}
+		// pinned posts are listed first, they aren't new
+		if item.Reason != nil && strings.HasSuffix(item.Reason.Type, "#reasonPin") {
+			continue
+		}
+
+		content := a.newContent(item, isRepost)
+		if _, ok := seen[content.ID]; ok {
+			continue
+		}
+		seen[content.ID] = struct{}{}
+		contents = append(contents, content)
+	}
+
+	// newest first, a repost counts from the time it was reposted
+	slices.SortStableFunc(contents, func(a, b model.Content) int {
+		return b.PublishedAt.Compare(a.PublishedAt)
+	})
+
+	return contents, nil
+}
+
+func (a *Adapter) newContent(item FeedItem, isRepost bool) model.Content {
+	post := item.Post
+	isReply := post.Record.Reply != nil
+
+	author := "@" + post.Author.Handle
+
+	title := fmt.Sprintf("Post by %s", author)
+	publishedAt := post.Record.CreatedAt
+	var repostedBy string
+	switch {
+	case isRepost:
+		repostedBy = item.Reason.By.Handle
+		title = fmt.Sprintf("Repost by @%s", repostedBy)
+		publishedAt = item.Reason.IndexedAt
+	case isReply:
+		title = fmt.Sprintf("Reply by %s", author)
+	}
+
+	// a repost is listed along with the post itself, so it's keyed by the repost
+	id := a.SourceID() + ":" + post.URI
+	if isRepost {
+		repost := item.Reason.URI
+		if repost == "" {
+			repost = post.URI + "@" + item.Reason.IndexedAt.UTC().Format(time.RFC3339)
+		}
+		id = a.SourceID() + ":repost:" + repost
+	}
+
+	embed := parseEmbed(post.Embed)
+
+	return model.Content{
+		ID:          id,
+		SourceID:    a.SourceID(),
+		Title:       title,
+		Description: post.Record.Text,
+		URL:         WebURL(post.URI, post.Author.Handle),
+		Author:      author,
+		Platform:    "Bluesky",
+		PublishedAt: publishedAt,
+		UpdatedAt:   time.Now(),
+		Metadata: map[string]interface{}{
+			"uri":           post.URI,
+			"cid":           post.CID,
+			"author_did":    post.Author.DID,
+			"author_handle": post.Author.Handle,
+			"reposted_by":   repostedBy,
+			"is_repost":     isRepost,
+			"is_reply":      isReply,
+			"is_quote":      embed.quotedURI != "",
+			"quoted_uri":    embed.quotedURI,
+			"embed_url":     embed.link,
+			"images":        strings.Join(embed.images, ","),
+			"image_alts":    strings.Join(embed.alts, "\n"),
+			"like_count":    post.LikeCount,
+			"repost_count":  post.RepostCount,
+			"reply_count":   post.ReplyCount,
+			"quote_count":   post.QuoteCount,
+		},
+	}
+}
+
+// WebURL maps an at:// post URI to its page on bsky.app,
+// e.g. at://did:plc:abc/app.bsky.feed.post/3k2a to https://bsky.app/profile/handle/post/3k2a
+func WebURL(uri, handle string) string {
+	repo, rest, _ := strings.Cut(strings.TrimPrefix(uri, "at://"), "/")
+	_, rkey, _ := strings.Cut(rest, "/")
+
+	profile := handle
+	if profile == "" || profile == "handle.invalid" {
+		profile = repo
+	}
+
+	return fmt.Sprintf("https://bsky.app/profile/%s/post/%s", profile, rkey)
+}
@@ -0,0 +1,150 @@
+package bluesky
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const testDID = "did:plc:abc123"
+
+// authorFeed has a pinned post, a post, the author's repost of that same post, a reply and a repost of another account
+const authorFeed = `{"feed": [
+	{"post": {"uri": "at://did:plc:abc123/app.bsky.feed.post/pinned", "author": {"did": "did:plc:abc123", "handle": "alice.test"},
+		"record": {"text": "pinned", "createdAt": "2026-01-01T00:00:00Z"}},
+	 "reason": {"$type": "app.bsky.feed.defs#reasonPin"}},
+	{"post": {"uri": "at://did:plc:abc123/app.bsky.feed.post/p1", "author": {"did": "did:plc:abc123", "handle": "alice.test"},
+		"record": {"text": "hello", "createdAt": "2026-03-01T00:00:00Z"}}},
+	{"post": {"uri": "at://did:plc:abc123/app.bsky.feed.post/p1", "author": {"did": "did:plc:abc123", "handle": "alice.test"},
+		"record": {"text": "hello", "createdAt": "2026-03-01T00:00:00Z"}},
+	 "reason": {"$type": "app.bsky.feed.defs#reasonRepost", "by": {"did": "did:plc:abc123", "handle": "alice.test"},
+		"indexedAt": "2026-03-02T00:00:00Z", "uri": "at://did:plc:abc123/app.bsky.feed.repost/r1"}},
+	{"post": {"uri": "at://did:plc:abc123/app.bsky.feed.post/p2", "author": {"did": "did:plc:abc123", "handle": "alice.test"},
+		"record": {"text": "reply", "createdAt": "2026-03-03T00:00:00Z", "reply": {"parent": {"uri": "at://did:plc:xyz/app.bsky.feed.post/q"}}}}},
+	{"post": {"uri": "at://did:plc:bob/app.bsky.feed.post/b1", "author": {"did": "did:plc:bob", "handle": "bob.test"},
+		"record": {"text": "bob's post", "createdAt": "2026-02-01T00:00:00Z"}},
+	 "reason": {"$type": "app.bsky.feed.defs#reasonRepost", "by": {"did": "did:plc:abc123", "handle": "alice.test"},
+		"indexedAt": "2026-03-04T00:00:00Z"}}
+]}`
+
+// newStandIn serves the XRPC methods used by the adapter, counting the handle resolutions
+func newStandIn(t *testing.T, resolutions *int) {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/xrpc/com.atproto.identity.resolveHandle":
+			*resolutions++
+			if r.URL.Query().Get("handle") != "alice.test" {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"error": "InvalidRequest", "message": "Unable to resolve handle"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"did": "` + testDID + `"}`))
+		case "/xrpc/app.bsky.feed.getAuthorFeed":
+			if r.URL.Query().Get("actor") != testDID {
+				t.Errorf("feed requested for %q, want the resolved DID", r.URL.Query().Get("actor"))
+			}
+			_, _ = w.Write([]byte(authorFeed))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	previous := appViewURL
+	appViewURL = server.URL + "/xrpc"
+	t.Cleanup(func() { appViewURL = previous })
+}
+
+func TestResolveHandle(t *testing.T) {
+	var resolutions int
+	newStandIn(t, &resolutions)
+
+	adapter, err := NewAdapter(&Config{Actor: "@Alice.test"}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := adapter.Fetch(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if got := adapter.State()[stateDID]; got != testDID {
+		t.Errorf("stored DID = %q, want %q", got, testDID)
+	}
+
+	// the stored DID skips the resolution
+	restored, _ := NewAdapter(&Config{Actor: "alice.test"}, "")
+	restored.LoadState(adapter.State())
+	if _, err := restored.Fetch(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if resolutions != 1 {
+		t.Errorf("resolved the handle %d times, want 1", resolutions)
+	}
+
+	unknown, _ := NewAdapter(&Config{Actor: "nobody.test"}, "")
+	if _, err := unknown.Fetch(context.Background()); err == nil {
+		t.Error("expected an error for an unknown handle")
+	}
+}
+
+func TestFetchRepostsAndPins(t *testing.T) {
+	var resolutions int
+	newStandIn(t, &resolutions)
+
+	adapter, err := NewAdapter(&Config{Actor: testDID}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	contents, err := adapter.Fetch(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resolutions != 0 {
+		t.Errorf("resolved a DID actor %d times", resolutions)
+	}
+
+	sourceID := adapter.SourceID()
+	want := []string{
+		sourceID + ":repost:at://did:plc:bob/app.bsky.feed.post/b1@2026-03-04T00:00:00Z",
+		sourceID + ":at://did:plc:abc123/app.bsky.feed.post/p2",
+		sourceID + ":repost:at://did:plc:abc123/app.bsky.feed.repost/r1",
+		sourceID + ":at://did:plc:abc123/app.bsky.feed.post/p1",
+	}
+	if len(contents) != len(want) {
+		t.Fatalf("got %d contents, want %d", len(contents), len(want))
+	}
+	for idx, content := range contents {
+		if content.ID != want[idx] {
+			t.Errorf("content %d: ID = %q, want %q", idx, content.ID, want[idx])
+		}
+	}
+
+	if contents[0].Title != "Repost by @alice.test" || contents[0].Metadata["reposted_by"] != "alice.test" {
+		t.Errorf("unexpected repost: %q %v", contents[0].Title, contents[0].Metadata["reposted_by"])
+	}
+	if contents[1].Title != "Reply by @alice.test" {
+		t.Errorf("unexpected reply title: %q", contents[1].Title)
+	}
+	if contents[3].URL != "https://bsky.app/profile/alice.test/post/p1" {
+		t.Errorf("unexpected URL: %q", contents[3].URL)
+	}
+
+	adapter.config.ExcludeReposts = true
+	contents, err = adapter.Fetch(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, content := range contents {
+		if strings.Contains(content.ID, ":repost:") {
+			t.Errorf("repost %q not excluded", content.ID)
+		}
+	}
+	if len(contents) != 2 {
+		t.Errorf("got %d contents without reposts, want 2", len(contents))
+	}
+}
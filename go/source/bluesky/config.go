@@ -0,0 +1,47 @@
+package bluesky
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/ryansiau/KeepUpdated/go/model"
+)
+
+var (
+	handleRegex = regexp.MustCompile(`^@?([a-zA-Z0-9]([a-zA-Z0-9-]*[a-zA-Z0-9])?\.)+[a-zA-Z]([a-zA-Z0-9-]*[a-zA-Z0-9])?$`)
+	didRegex    = regexp.MustCompile(`^did:[a-z]+:[a-zA-Z0-9._:%-]+$`)
+)
+
+// Config represents the configuration for a Bluesky source
+type Config struct {
+	// Actor is the handle, e.g. bsky.app, or the DID of the account
+	Actor          string `yaml:"actor" mapstructure:"actor"`
+	ExcludeReplies bool   `yaml:"exclude_replies" mapstructure:"exclude_replies"`
+	ExcludeReposts bool   `yaml:"exclude_reposts" mapstructure:"exclude_reposts"`
+}
+
+// Validate validates the Bluesky source configuration
+func (c *Config) Validate() error {
+	if c.Actor == "" {
+		return fmt.Errorf("actor is required")
+	}
+	if !handleRegex.MatchString(c.Actor) && !didRegex.MatchString(c.Actor) {
+		return fmt.Errorf("actor has to be a handle or a DID: %s", c.Actor)
+	}
+	return nil
+}
+
+func (c *Config) IsCrawler() {}
+
+func (c *Config) Build(name string) (model.Source, error) {
+	return NewAdapter(c, name)
+}
+
+// actor returns the handle without the leading @ and in lowercase, or the DID as is
+func (c *Config) actor() string {
+	if strings.HasPrefix(c.Actor, "did:") {
+		return c.Actor
+	}
+	return strings.ToLower(strings.TrimPrefix(c.Actor, "@"))
+}
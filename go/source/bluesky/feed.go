@@ -0,0 +1,117 @@
+package bluesky
+
+import (
+	"strings"
+	"time"
+)
+
+// FeedItem is an item of app.bsky.feed.getAuthorFeed
+type FeedItem struct {
+	Post   PostView `json:"post"`
+	Reason *struct {
+		Type string `json:"$type"`
+		By   struct {
+			DID    string `json:"did"`
+			Handle string `json:"handle"`
+		} `json:"by"`
+		IndexedAt time.Time `json:"indexedAt"`
+		// URI is the repost record of a reasonRepost, left out by older AppViews
+		URI string `json:"uri"`
+	} `json:"reason"`
+}
+
+// PostView is the hydrated view of a post
+type PostView struct {
+	URI    string `json:"uri"`
+	CID    string `json:"cid"`
+	Author struct {
+		DID         string `json:"did"`
+		Handle      string `json:"handle"`
+		DisplayName string `json:"displayName"`
+	} `json:"author"`
+	Record struct {
+		Text      string    `json:"text"`
+		CreatedAt time.Time `json:"createdAt"`
+		Reply     *struct {
+			Parent struct {
+				URI string `json:"uri"`
+			} `json:"parent"`
+		} `json:"reply"`
+	} `json:"record"`
+	Embed       *EmbedView `json:"embed"`
+	ReplyCount  int        `json:"replyCount"`
+	RepostCount int        `json:"repostCount"`
+	LikeCount   int        `json:"likeCount"`
+	QuoteCount  int        `json:"quoteCount"`
+}
+
+// EmbedView holds the fields of every embed view type, only the ones of its $type are set:
+// app.bsky.embed.images#view, external#view, record#view, recordWithMedia#view and video#view
+type EmbedView struct {
+	Type   string `json:"$type"`
+	Images []struct {
+		Fullsize string `json:"fullsize"`
+		Alt      string `json:"alt"`
+	} `json:"images"`
+	External *struct {
+		URI   string `json:"uri"`
+		Title string `json:"title"`
+	} `json:"external"`
+	// Record is the quoted post of record#view, or the record#view itself within recordWithMedia#view
+	Record *struct {
+		URI    string `json:"uri"`
+		Record *struct {
+			URI string `json:"uri"`
+		} `json:"record"`
+	} `json:"record"`
+	Media *EmbedView `json:"media"`
+	// Alt and Playlist are set by video#view
+	Alt      string `json:"alt"`
+	Playlist string `json:"playlist"`
+}
+
+type embedInfo struct {
+	link      string
+	quotedURI string
+	images    []string
+	alts      []string
+}
+
+// parseEmbed collects the links, the quoted post and the images with their alt text
+func parseEmbed(embed *EmbedView) embedInfo {
+	var info embedInfo
+	if embed == nil {
+		return info
+	}
+
+	switch {
+	case strings.HasPrefix(embed.Type, "app.bsky.embed.images"):
+		for _, image := range embed.Images {
+			info.images = append(info.images, image.Fullsize)
+			if image.Alt != "" {
+				info.alts = append(info.alts, image.Alt)
+			}
+		}
+	case strings.HasPrefix(embed.Type, "app.bsky.embed.external"):
+		if embed.External != nil {
+			info.link = embed.External.URI
+		}
+	case strings.HasPrefix(embed.Type, "app.bsky.embed.video"):
+		info.link = embed.Playlist
+		if embed.Alt != "" {
+			info.alts = append(info.alts, embed.Alt)
+		}
+	case strings.HasPrefix(embed.Type, "app.bsky.embed.recordWithMedia"):
+		if embed.Record != nil && embed.Record.Record != nil {
+			info.quotedURI = embed.Record.Record.URI
+		}
+		media := parseEmbed(embed.Media)
+		info.link, info.images, info.alts = media.link, media.images, media.alts
+	case strings.HasPrefix(embed.Type, "app.bsky.embed.record"):
+		if embed.Record != nil {
+			info.quotedURI = embed.Record.URI
+		}
+	}
+
+	return info
+}
@@ -7,8 +7,25 @@ import (
 	"github.com/mitchellh/mapstructure"
 
 	"github.com/ryansiau/KeepUpdated/go/model"
+	"github.com/ryansiau/KeepUpdated/go/source/bluesky"
+	container_image "github.com/ryansiau/KeepUpdated/go/source/container-image"
+	"github.com/ryansiau/KeepUpdated/go/source/exec"
+	"github.com/ryansiau/KeepUpdated/go/source/filesystem"
 	generic_rss "github.com/ryansiau/KeepUpdated/go/source/generic-rss"
+	"github.com/ryansiau/KeepUpdated/go/source/gitea"
+	"github.com/ryansiau/KeepUpdated/go/source/github"
+	"github.com/ryansiau/KeepUpdated/go/source/gitlab"
+	"github.com/ryansiau/KeepUpdated/go/source/graphql"
+	"github.com/ryansiau/KeepUpdated/go/source/hackernews"
+	"github.com/ryansiau/KeepUpdated/go/source/ical"
+	"github.com/ryansiau/KeepUpdated/go/source/imap"
+	json_api "github.com/ryansiau/KeepUpdated/go/source/json-api"
+	"github.com/ryansiau/KeepUpdated/go/source/mastodon"
+	package_registry "github.com/ryansiau/KeepUpdated/go/source/package-registry"
 	"github.com/ryansiau/KeepUpdated/go/source/reddit"
+	"github.com/ryansiau/KeepUpdated/go/source/twitter"
+	"github.com/ryansiau/KeepUpdated/go/source/webhook"
+	"github.com/ryansiau/KeepUpdated/go/source/webpage"
 	"github.com/ryansiau/KeepUpdated/go/source/youtube"
 )
 
@@ -25,6 +42,23 @@ func (c *BaseConfig) Validate() error {
 		"youtube",
 		"reddit",
 		"rss",
+		"twitter",
+		"github",
+		"gitlab",
+		"gitea",
+		"package",
+		"container_image",
+		"hackernews",
+		"mastodon",
+		"bluesky",
+		"webpage",
+		"json_api",
+		"graphql",
+		"webhook",
+		"imap",
+		"exec",
+		"filesystem",
+		"ical",
 	}
 
 	// make sure the source type is valid
@@ -78,6 +112,125 @@ func (c *BaseConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
 			return err
 		}
 		c.Config = &cfg
+	case "github":
+		var cfg github.Config
+		err := mapstructure.Decode(raw.Config, &cfg)
+		if err != nil {
+			return err
+		}
+		c.Config = &cfg
+	case "gitlab":
+		var cfg gitlab.Config
+		err := mapstructure.Decode(raw.Config, &cfg)
+		if err != nil {
+			return err
+		}
+		c.Config = &cfg
+	case "gitea":
+		var cfg gitea.Config
+		err := mapstructure.Decode(raw.Config, &cfg)
+		if err != nil {
+			return err
+		}
+		c.Config = &cfg
+	case "package":
+		var cfg package_registry.Config
+		err := mapstructure.Decode(raw.Config, &cfg)
+		if err != nil {
+			return err
+		}
+		c.Config = &cfg
+	case "container_image":
+		var cfg container_image.Config
+		err := mapstructure.Decode(raw.Config, &cfg)
+		if err != nil {
+			return err
+		}
+		c.Config = &cfg
+	case "hackernews":
+		var cfg hackernews.Config
+		err := mapstructure.Decode(raw.Config, &cfg)
+		if err != nil {
+			return err
+		}
+		c.Config = &cfg
+	case "mastodon":
+		var cfg mastodon.Config
+		err := mapstructure.Decode(raw.Config, &cfg)
+		if err != nil {
+			return err
+		}
+		c.Config = &cfg
+	case "bluesky":
+		var cfg bluesky.Config
+		err := mapstructure.Decode(raw.Config, &cfg)
+		if err != nil {
+			return err
+		}
+		c.Config = &cfg
+	case "webpage":
+		var cfg webpage.Config
+		err := mapstructure.Decode(raw.Config, &cfg)
+		if err != nil {
+			return err
+		}
+		c.Config = &cfg
+	case "json_api":
+		var cfg json_api.Config
+		err := mapstructure.Decode(raw.Config, &cfg)
+		if err != nil {
+			return err
+		}
+		c.Config = &cfg
+	case "graphql":
+		var cfg graphql.Config
+		err := mapstructure.Decode(raw.Config, &cfg)
+		if err != nil {
+			return err
+		}
+		c.Config = &cfg
+	case "webhook":
+		var cfg webhook.Config
+		err := mapstructure.Decode(raw.Config, &cfg)
+		if err != nil {
+			return err
+		}
+		c.Config = &cfg
+	case "imap":
+		var cfg imap.Config
+		err := mapstructure.Decode(raw.Config, &cfg)
+		if err != nil {
+			return err
+		}
+		c.Config = &cfg
+	case "exec":
+		var cfg exec.Config
+		err := mapstructure.Decode(raw.Config, &cfg)
+		if err != nil {
+			return err
+		}
+		c.Config = &cfg
+	case "filesystem":
+		var cfg filesystem.Config
+		err := mapstructure.Decode(raw.Config, &cfg)
+		if err != nil {
+			return err
+		}
+		c.Config = &cfg
+	case "ical":
+		var cfg ical.Config
+		err := mapstructure.Decode(raw.Config, &cfg)
+		if err != nil {
+			return err
+		}
+		c.Config = &cfg
+	case "twitter":
+		var cfg twitter.Config
+		err := mapstructure.Decode(raw.Config, &cfg)
+		if err != nil {
+			return err
+		}
+		c.Config = &cfg
 	default:
 		return fmt.Errorf("unrecognized config type: %s", c.Type)
 	}
@@ -0,0 +1,221 @@
+package container_image
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"resty.dev/v3"
+
+	"github.com/ryansiau/KeepUpdated/go/common"
+	"github.com/ryansiau/KeepUpdated/go/model"
+)
+
+// maxTags is the number of latest tags returned on every fetch
+const maxTags = 50
+
+// Tag is a tag of the image. Digest and PushedAt are only known for some registries.
+type Tag struct {
+	Name     string
+	Digest   string
+	PushedAt time.Time
+}
+
+// Adapter implements the Source interface for container images
+type Adapter struct {
+	client *resty.Client
+	config *Config
+	name   string
+
+	tagRegex   *regexp.Regexp
+	constraint *semver.Constraints
+
+	// token authenticates the requests of this execution to the registry,
+	// hubToken the ones to the Docker Hub API
+	token    string
+	hubToken string
+}
+
+// NewAdapter creates a new container image adapter with configuration
+func NewAdapter(config *Config, name string) (*Adapter, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	if name == "" {
+		name = "Image: " + config.Image
+	}
+
+	adapter := &Adapter{
+		client: resty.New().
+			SetTimeout(30*time.Second).
+			SetHeader("User-Agent", common.HTTPClientUserAgent),
+		config: config,
+		name:   name,
+	}
+
+	if config.TagRegex != "" {
+		adapter.tagRegex = regexp.MustCompile(config.TagRegex)
+	}
+	if config.SemverConstraint != "" {
+		constraint, err := semver.NewConstraint(config.SemverConstraint)
+		if err != nil {
+			return nil, err
+		}
+		adapter.constraint = constraint
+	}
+
+	return adapter, nil
+}
+
+// Name returns the name of the source
+func (a *Adapter) Name() string {
+	return a.name
+}
+
+// Type returns the platform type
+func (a *Adapter) Type() string {
+	return "container_image"
+}
+
+// SourceID returns the identifier of the source, the filters are part of it as they pick different tags
+func (a *Adapter) SourceID() string {
+	id := fmt.Sprintf("ContainerImage:%s/%s", a.config.registry(), a.config.repository())
+	if a.config.TagRegex != "" {
+		id += ":tag_regex=" + a.config.TagRegex
+	}
+	if a.config.SemverConstraint != "" {
+		id += ":semver_constraint=" + a.config.SemverConstraint
+	}
+	return id
+}
+
+// FetchTags lists the tags of the image, newest first when the registry tells
+func (a *Adapter) FetchTags(ctx context.Context) ([]Tag, error) {
+	if a.config.registry() == dockerHubRegistry {
+		return a.fetchDockerHubTags(ctx)
+	}
+	return a.fetchRegistryTags(ctx)
+}
+
+// Fetch retrieves the new tags of the image, and the digests of the watched tags
+func (a *Adapter) Fetch(ctx context.Context) ([]model.Content, error) {
+	tags, err := a.FetchTags(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	image := a.config.registry() + "/" + a.config.repository()
+
+	// the distribution API doesn't expose any date, the time of discovery is the best approximation.
+	// all of them share it so the registry's order survives the sorting below.
+	now := time.Now()
+	for i := range tags {
+		if tags[i].PushedAt.IsZero() {
+			tags[i].PushedAt = now
+		}
+	}
+
+	var contents []model.Content
+	for _, tag := range tags {
+		if slices.Contains(a.config.WatchTags, tag.Name) {
+			continue
+		}
+		if !a.matches(tag.Name) {
+			continue
+		}
+
+		contents = append(contents, a.newContent(image, tag, a.SourceID()+":"+tag.Name))
+		if len(contents) == maxTags {
+			break
+		}
+	}
+
+	// moving tags get a new id every time their digest changes
+	for _, name := range a.config.WatchTags {
+		idx := slices.IndexFunc(tags, func(tag Tag) bool {
+			return tag.Name == name
+		})
+		if idx < 0 {
+			continue
+		}
+
+		tag := tags[idx]
+		if tag.Digest == "" {
+			tag.Digest, err = a.fetchDigest(ctx, name)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		contents = append(contents, a.newContent(image, tag, a.SourceID()+":"+tag.Name+"@"+tag.Digest))
+	}
+
+	// newest first
+	slices.SortStableFunc(contents, func(a, b model.Content) int {
+		return b.PublishedAt.Compare(a.PublishedAt)
+	})
+
+	return contents, nil
+}
+
+func (a *Adapter) newContent(image string, tag Tag, id string) model.Content {
+	title := fmt.Sprintf("%s:%s", image, tag.Name)
+	if slices.Contains(a.config.WatchTags, tag.Name) {
+		title = fmt.Sprintf("%s:%s updated", image, tag.Name)
+	}
+
+	return model.Content{
+		ID:          id,
+		SourceID:    a.SourceID(),
+		Title:       title,
+		Description: tag.Digest,
+		URL:         a.tagURL(tag.Name),
+		Author:      a.config.Image,
+		Platform:    "Container Image",
+		PublishedAt: tag.PushedAt,
+		UpdatedAt:   time.Now(),
+		Metadata: map[string]interface{}{
+			"image":  image,
+			"tag":    tag.Name,
+			"digest": tag.Digest,
+		},
+	}
+}
+
+// matches checks the tag against tag_regex and semver_constraint
+func (a *Adapter) matches(tag string) bool {
+	if a.tagRegex != nil && !a.tagRegex.MatchString(tag) {
+		return false
+	}
+	if a.constraint != nil {
+		version, err := semver.NewVersion(tag)
+		if err != nil || !a.constraint.Check(version) {
+			return false
+		}
+	}
+	return true
+}
+
+// tagURL returns a page showing the tag, when the registry has one
+func (a *Adapter) tagURL(tag string) string {
+	repository := a.config.repository()
+
+	switch a.config.registry() {
+	case dockerHubRegistry:
+		if name, found := strings.CutPrefix(repository, "library/"); found {
+			return fmt.Sprintf("https://hub.docker.com/_/%s/tags?name=%s", name, tag)
+		}
+		return fmt.Sprintf("https://hub.docker.com/r/%s/tags?name=%s", repository, tag)
+	case "ghcr.io":
+		return fmt.Sprintf("https://ghcr.io/%s", repository)
+	case "quay.io":
+		return fmt.Sprintf("https://quay.io/repository/%s?tab=tags", repository)
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,217 @@
+package container_image
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ryansiau/KeepUpdated/go/model"
+)
+
+// registryStandIn serves the distribution API of owner/app and library/nginx behind a bearer challenge,
+// along with the Docker Hub API of library/nginx
+type registryStandIn struct {
+	server *httptest.Server
+	// digests are the digests of the manifests by tag
+	digests map[string]string
+
+	tokenRequests    int
+	manifestRequests int
+}
+
+func newRegistryStandIn(t *testing.T) *registryStandIn {
+	t.Helper()
+
+	s := &registryStandIn{digests: map[string]string{"latest": "sha256:aaa", "mainline": "sha256:ccc"}}
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP(t)))
+	t.Cleanup(s.server.Close)
+
+	previousHubURL, previousHubRegistryURL := dockerHubURL, dockerHubRegistryURL
+	dockerHubURL = s.server.URL
+	dockerHubRegistryURL = s.server.URL
+	t.Cleanup(func() {
+		dockerHubURL, dockerHubRegistryURL = previousHubURL, previousHubRegistryURL
+	})
+
+	return s
+}
+
+func (s *registryStandIn) serveHTTP(t *testing.T) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.URL.Path == "/token":
+			s.tokenRequests++
+			if r.URL.Query().Get("service") != "registry.test" || !strings.HasPrefix(r.URL.Query().Get("scope"), "repository:") {
+				t.Errorf("unexpected token request: %s", r.URL.RawQuery)
+			}
+			if username, password, ok := r.BasicAuth(); ok && (username != "user" || password != "pass") {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_, _ = w.Write([]byte(`{"token": "registry-token"}`))
+			return
+		case r.URL.Path == "/v2/users/login":
+			_, _ = w.Write([]byte(`{"token": "hub-token"}`))
+			return
+		case r.URL.Path == "/v2/repositories/library/nginx/tags":
+			if r.Header.Get("Authorization") != "Bearer hub-token" {
+				t.Errorf("Docker Hub API requested with %q", r.Header.Get("Authorization"))
+			}
+			_, _ = w.Write([]byte(`{"results": [
+				{"name": "1.27.0", "digest": "sha256:111", "tag_last_pushed": "2026-05-01T00:00:00Z"},
+				{"name": "latest", "digest": "sha256:bbb", "tag_last_pushed": "2026-05-01T00:00:00Z"},
+				{"name": "mainline", "last_updated": "2026-05-02T00:00:00Z"},
+				{"name": "1.26.0", "digest": "sha256:222", "tag_last_pushed": "2026-01-01T00:00:00Z"}
+			]}`))
+			return
+		}
+
+		// the distribution API requires the token of the challenge
+		if r.Header.Get("Authorization") != "Bearer registry-token" {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="registry.test",scope="repository:owner/app:pull"`, s.server.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch {
+		case r.URL.Path == "/v2/owner/app/tags/list" && r.URL.Query().Get("last") == "":
+			w.Header().Set("Link", `</v2/owner/app/tags/list?n=1000&last=latest>; rel="next"`)
+			_, _ = w.Write([]byte(`{"name": "owner/app", "tags": ["1.0.0", "1.2.0", "latest"]}`))
+		case r.URL.Path == "/v2/owner/app/tags/list":
+			_, _ = w.Write([]byte(`{"name": "owner/app", "tags": ["2.0.0", "edge"]}`))
+		case strings.HasPrefix(r.URL.Path, "/v2/") && strings.Contains(r.URL.Path, "/manifests/"):
+			s.manifestRequests++
+			tag := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+			digest, ok := s.digests[tag]
+			if !ok || r.Method != http.MethodHead {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Header().Set("Docker-Content-Digest", digest)
+		default:
+			http.NotFound(w, r)
+		}
+	}
+}
+
+func ids(contents []model.Content) []string {
+	var ids []string
+	for _, content := range contents {
+		ids = append(ids, content.ID)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+func TestRegistryTags(t *testing.T) {
+	s := newRegistryStandIn(t)
+	host := strings.TrimPrefix(s.server.URL, "http://")
+
+	adapter, err := NewAdapter(&Config{Image: host + "/owner/app", PlainHTTP: true, WatchTags: []string{"latest"}}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	contents, err := adapter.Fetch(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sourceID := adapter.SourceID()
+	want := []string{
+		sourceID + ":1.0.0",
+		sourceID + ":1.2.0",
+		sourceID + ":2.0.0",
+		sourceID + ":edge",
+		sourceID + ":latest@sha256:aaa",
+	}
+	if got := ids(contents); !slices.Equal(got, want) {
+		t.Errorf("IDs = %v, want both pages and the digest of the watched tag %v", got, want)
+	}
+	if s.tokenRequests != 1 {
+		t.Errorf("requested %d tokens, want the one of the challenge to be reused", s.tokenRequests)
+	}
+}
+
+func TestWatchTagDigest(t *testing.T) {
+	s := newRegistryStandIn(t)
+	host := strings.TrimPrefix(s.server.URL, "http://")
+
+	config := &Config{Image: host + "/owner/app", PlainHTTP: true, TagRegex: `^\d`, WatchTags: []string{"latest"}}
+	fetch := func() []string {
+		t.Helper()
+
+		adapter, err := NewAdapter(config, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		contents, err := adapter.Fetch(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ids(contents)
+	}
+
+	first := fetch()
+	if len(first) != 4 || slices.Contains(first, "edge") {
+		t.Errorf("IDs = %v, want the versions matching tag_regex and the watched tag", first)
+	}
+	for _, id := range first {
+		if !strings.HasPrefix(id, "ContainerImage:"+host+"/owner/app:tag_regex=^\\d:") {
+			t.Errorf("ID %q isn't namespaced by the filtered source", id)
+		}
+	}
+
+	// the same digest keeps the id, a new one is a new content
+	if second := fetch(); !slices.Equal(first, second) {
+		t.Errorf("IDs changed without a new digest: %v, then %v", first, second)
+	}
+	s.digests["latest"] = "sha256:ddd"
+	third := fetch()
+	if slices.Equal(first, third) || !strings.HasSuffix(third[len(third)-1], ":latest@sha256:ddd") {
+		t.Errorf("IDs = %v, want the new digest of the watched tag", third)
+	}
+}
+
+func TestDockerHub(t *testing.T) {
+	s := newRegistryStandIn(t)
+
+	adapter, err := NewAdapter(&Config{Image: "nginx", Username: "user", Password: "pass", WatchTags: []string{"latest", "mainline"}}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if adapter.SourceID() != "ContainerImage:docker.io/library/nginx" {
+		t.Errorf("SourceID = %q", adapter.SourceID())
+	}
+
+	contents, err := adapter.Fetch(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sourceID := adapter.SourceID()
+	want := []string{
+		sourceID + ":1.26.0",
+		sourceID + ":1.27.0",
+		sourceID + ":latest@sha256:bbb",
+		sourceID + ":mainline@sha256:ccc",
+	}
+	if got := ids(contents); !slices.Equal(got, want) {
+		t.Errorf("IDs = %v, want %v", got, want)
+	}
+
+	// only the tag without a digest in the Docker Hub API is resolved through the registry
+	if s.manifestRequests != 1 {
+		t.Errorf("resolved %d digests through the registry, want 1", s.manifestRequests)
+	}
+
+	if contents[0].URL != "https://hub.docker.com/_/nginx/tags?name=mainline" {
+		t.Errorf("URL of the latest content = %q", contents[0].URL)
+	}
+}
@@ -0,0 +1,86 @@
+package container_image
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+
+	"github.com/ryansiau/KeepUpdated/go/model"
+)
+
+// dockerHubRegistry is the registry of images without an explicit registry host
+const dockerHubRegistry = "docker.io"
+
+// Config represents the configuration for a container image source
+type Config struct {
+	// Image without tag, e.g. nginx, grafana/grafana or ghcr.io/owner/image
+	Image string `yaml:"image" mapstructure:"image"`
+	// Username and Password are optional, the password can also be a personal access token
+	Username string `yaml:"username" mapstructure:"username"`
+	Password string `yaml:"password" mapstructure:"password"`
+	// TagRegex and SemverConstraint (e.g. ">= 1.25, < 2") limit which new tags are notified
+	TagRegex         string `yaml:"tag_regex" mapstructure:"tag_regex"`
+	SemverConstraint string `yaml:"semver_constraint" mapstructure:"semver_constraint"`
+	// WatchTags are moving tags, e.g. latest, notified every time their digest changes
+	WatchTags []string `yaml:"watch_tags" mapstructure:"watch_tags"`
+	// PlainHTTP talks to the registry over HTTP instead of HTTPS, e.g. for a local registry
+	PlainHTTP bool `yaml:"plain_http" mapstructure:"plain_http"`
+}
+
+// Validate validates the container image source configuration
+func (c *Config) Validate() error {
+	if c.Image == "" {
+		return fmt.Errorf("image is required")
+	}
+	if strings.ContainsAny(c.Image, "@ ") || strings.Contains(c.repository(), ":") {
+		return fmt.Errorf("image has to be given without tag or digest: %s", c.Image)
+	}
+	if c.TagRegex != "" {
+		if _, err := regexp.Compile(c.TagRegex); err != nil {
+			return fmt.Errorf("invalid tag_regex: %w", err)
+		}
+	}
+	if c.SemverConstraint != "" {
+		if _, err := semver.NewConstraint(c.SemverConstraint); err != nil {
+			return fmt.Errorf("invalid semver_constraint: %w", err)
+		}
+	}
+	if (c.Username == "") != (c.Password == "") {
+		return fmt.Errorf("username and password have to be set together")
+	}
+	return nil
+}
+
+func (c *Config) IsCrawler() {}
+
+func (c *Config) Build(name string) (model.Source, error) {
+	return NewAdapter(c, name)
+}
+
+// registry returns the registry host of the image, docker.io when there is none
+func (c *Config) registry() string {
+	first, _, found := strings.Cut(c.Image, "/")
+	if found && (strings.ContainsAny(first, ".:") || first == "localhost") {
+		if first == "index.docker.io" || first == "registry-1.docker.io" {
+			return dockerHubRegistry
+		}
+		return first
+	}
+	return dockerHubRegistry
+}
+
+// repository returns the image name within its registry.
+// official Docker Hub images live in the "library" namespace.
+func (c *Config) repository() string {
+	repository := c.Image
+	if first, rest, found := strings.Cut(c.Image, "/"); found && (strings.ContainsAny(first, ".:") || first == "localhost") {
+		repository = rest
+	}
+
+	if c.registry() == dockerHubRegistry && !strings.Contains(repository, "/") {
+		repository = "library/" + repository
+	}
+	return repository
+}
@@ -0,0 +1,93 @@
+package container_image
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// dockerHubURL serves the Docker Hub API, dockerHubRegistryURL the distribution API of its images.
+// they are variables so a local stand-in server can take their place.
+var (
+	dockerHubURL         = "https://hub.docker.com"
+	dockerHubRegistryURL = "https://registry-1.docker.io"
+)
+
+// fetchDockerHubTags lists the tags through the Docker Hub API, which unlike the
+// distribution API exposes the push time and the digest of every tag
+func (a *Adapter) fetchDockerHubTags(ctx context.Context) ([]Tag, error) {
+	if a.config.Username != "" && a.hubToken == "" {
+		if err := a.dockerHubLogin(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	var res struct {
+		Results []struct {
+			Name          string    `json:"name"`
+			Digest        string    `json:"digest"`
+			LastUpdated   time.Time `json:"last_updated"`
+			TagLastPushed time.Time `json:"tag_last_pushed"`
+		} `json:"results"`
+	}
+
+	req := a.client.R().
+		SetContext(ctx).
+		SetResult(&res).
+		SetQueryParam("page_size", "100").
+		SetQueryParam("ordering", "last_updated")
+	if a.hubToken != "" {
+		req.SetAuthToken(a.hubToken)
+	}
+
+	resp, err := req.Get(fmt.Sprintf("%s/v2/repositories/%s/tags", dockerHubURL, a.config.repository()))
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode() != 200 {
+		return nil, fmt.Errorf("status code: %d, body: %s", resp.StatusCode(), resp.String())
+	}
+
+	var tags []Tag
+	for _, result := range res.Results {
+		pushedAt := result.TagLastPushed
+		if pushedAt.IsZero() {
+			pushedAt = result.LastUpdated
+		}
+
+		tags = append(tags, Tag{
+			Name:     result.Name,
+			Digest:   result.Digest,
+			PushedAt: pushedAt,
+		})
+	}
+
+	return tags, nil
+}
+
+// dockerHubLogin exchanges the credentials for a Docker Hub token, needed by private repositories
+func (a *Adapter) dockerHubLogin(ctx context.Context) error {
+	var res struct {
+		Token string `json:"token"`
+	}
+
+	resp, err := a.client.R().
+		SetContext(ctx).
+		SetBody(map[string]string{
+			"username": a.config.Username,
+			"password": a.config.Password,
+		}).
+		SetResult(&res).
+		Post(dockerHubURL + "/v2/users/login")
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode() != 200 {
+		return fmt.Errorf("failed to log in to Docker Hub, status code: %d, body: %s", resp.StatusCode(), resp.String())
+	}
+
+	a.hubToken = res.Token
+	return nil
+}
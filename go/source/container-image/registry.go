@@ -0,0 +1,196 @@
+package container_image
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"resty.dev/v3"
+)
+
+// maxTagPages caps how many pages of tags are read from the distribution API
+const maxTagPages = 10
+
+var (
+	challengeParamRegex = regexp.MustCompile(`(\w+)="([^"]*)"`)
+	nextLinkRegex       = regexp.MustCompile(`<([^>]+)>;\s*rel="next"`)
+)
+
+// manifestMediaTypes are accepted when resolving a digest, so the registry doesn't convert the manifest
+var manifestMediaTypes = []string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+// registryURL returns the base URL of the registry, Docker Hub serving its images from another host
+func (a *Adapter) registryURL() string {
+	if a.config.registry() == dockerHubRegistry {
+		return dockerHubRegistryURL
+	}
+
+	scheme := "https"
+	if a.config.PlainHTTP {
+		scheme = "http"
+	}
+	return scheme + "://" + a.config.registry()
+}
+
+// fetchRegistryTags lists the tags through the OCI distribution API (/v2/<name>/tags/list)
+func (a *Adapter) fetchRegistryTags(ctx context.Context) ([]Tag, error) {
+	var names []string
+
+	next := fmt.Sprintf("/v2/%s/tags/list?n=1000", a.config.repository())
+	for page := 0; page < maxTagPages && next != ""; page++ {
+		var res struct {
+			Tags []string `json:"tags"`
+		}
+
+		resp, err := a.registryRequest(ctx, http.MethodGet, next, &res)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode() != 200 {
+			return nil, fmt.Errorf("status code: %d, body: %s", resp.StatusCode(), resp.String())
+		}
+
+		names = append(names, res.Tags...)
+
+		next = ""
+		if match := nextLinkRegex.FindStringSubmatch(resp.Header().Get("Link")); match != nil {
+			next = strings.TrimPrefix(match[1], a.registryURL())
+		}
+	}
+
+	// the distribution API lists tags in lexical order, versions are sorted newest first instead
+	slices.SortStableFunc(names, func(a, b string) int {
+		va, errA := semver.NewVersion(a)
+		vb, errB := semver.NewVersion(b)
+		if errA == nil && errB == nil {
+			return vb.Compare(va)
+		}
+		return strings.Compare(b, a)
+	})
+
+	var tags []Tag
+	for _, name := range names {
+		tags = append(tags, Tag{Name: name})
+	}
+
+	return tags, nil
+}
+
+// fetchDigest resolves the digest of a tag
+func (a *Adapter) fetchDigest(ctx context.Context, tag string) (string, error) {
+	resp, err := a.registryRequest(ctx, http.MethodHead, fmt.Sprintf("/v2/%s/manifests/%s", a.config.repository(), tag), nil)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode() != 200 {
+		return "", fmt.Errorf("failed to resolve digest of %s, status code: %d", tag, resp.StatusCode())
+	}
+
+	digest := resp.Header().Get("Docker-Content-Digest")
+	if digest == "" {
+		return "", fmt.Errorf("registry didn't return the digest of %s", tag)
+	}
+
+	return digest, nil
+}
+
+// registryRequest sends a request to the registry, answering its authentication challenge if needed
+func (a *Adapter) registryRequest(ctx context.Context, method, path string, result interface{}) (*resty.Response, error) {
+	newRequest := func() *resty.Request {
+		req := a.client.R().
+			SetContext(ctx).
+			SetHeader("Accept", strings.Join(manifestMediaTypes, ", ")).
+			SetForceResponseContentType("application/json")
+		if result != nil {
+			req.SetResult(result)
+		}
+		if a.token != "" {
+			req.SetAuthToken(a.token)
+		} else if a.config.Username != "" {
+			req.SetBasicAuth(a.config.Username, a.config.Password)
+		}
+		return req
+	}
+
+	resp, err := newRequest().Execute(method, a.registryURL()+path)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() != http.StatusUnauthorized || a.token != "" {
+		return resp, nil
+	}
+
+	challenge := resp.Header().Get("WWW-Authenticate")
+	if !strings.HasPrefix(strings.ToLower(challenge), "bearer ") {
+		return resp, nil
+	}
+
+	if err := a.fetchRegistryToken(ctx, challenge); err != nil {
+		return nil, err
+	}
+
+	return newRequest().Execute(method, a.registryURL()+path)
+}
+
+// fetchRegistryToken requests a token from the realm of a Bearer challenge,
+// e.g. Bearer realm="https://ghcr.io/token",service="ghcr.io",scope="repository:owner/image:pull"
+func (a *Adapter) fetchRegistryToken(ctx context.Context, challenge string) error {
+	params := map[string]string{}
+	for _, match := range challengeParamRegex.FindAllStringSubmatch(challenge, -1) {
+		params[strings.ToLower(match[1])] = match[2]
+	}
+
+	realm := params["realm"]
+	if realm == "" {
+		return fmt.Errorf("registry challenge without realm: %s", challenge)
+	}
+
+	scope := params["scope"]
+	if scope == "" {
+		scope = fmt.Sprintf("repository:%s:pull", a.config.repository())
+	}
+
+	var res struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+
+	req := a.client.R().
+		SetContext(ctx).
+		SetQueryParam("scope", scope).
+		SetResult(&res).
+		SetForceResponseContentType("application/json")
+	if params["service"] != "" {
+		req.SetQueryParam("service", params["service"])
+	}
+	if a.config.Username != "" {
+		req.SetBasicAuth(a.config.Username, a.config.Password)
+	}
+
+	resp, err := req.Get(realm)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode() != 200 {
+		return fmt.Errorf("failed to get registry token, status code: %d, body: %s", resp.StatusCode(), resp.String())
+	}
+
+	a.token = res.Token
+	if a.token == "" {
+		a.token = res.AccessToken
+	}
+	if a.token == "" {
+		return fmt.Errorf("registry returned an empty token")
+	}
+
+	return nil
+}
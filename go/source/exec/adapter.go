@@ -0,0 +1,148 @@
+// Package exec runs a custom script and reads the contents it prints as JSON Lines,
+// an escape hatch for one-off scrapers written in any language.
+package exec
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	osexec "os/exec"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ryansiau/KeepUpdated/go/model"
+	"github.com/ryansiau/KeepUpdated/go/pkg/jsonitem"
+)
+
+// Limits of the output read from the command
+const (
+	maxLineSize   = 1 << 20
+	maxStderrSize = 1 << 10
+)
+
+// Adapter implements the Source interface for commands
+type Adapter struct {
+	config *Config
+	name   string
+}
+
+// NewAdapter creates a new exec adapter with configuration
+func NewAdapter(config *Config, name string) (*Adapter, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	if name == "" {
+		name = fmt.Sprintf("Exec: %s", config.Command)
+	}
+
+	return &Adapter{
+		config: config,
+		name:   name,
+	}, nil
+}
+
+// Name returns the name of the source
+func (a *Adapter) Name() string {
+	return a.name
+}
+
+// Type returns the platform type
+func (a *Adapter) Type() string {
+	return "exec"
+}
+
+// SourceID returns the identifier of the source, made of the command line
+func (a *Adapter) SourceID() string {
+	return "Exec:" + strings.Join(append([]string{a.config.Command}, a.config.Args...), " ")
+}
+
+// Fetch runs the command and converts every line of its output into a content
+func (a *Adapter) Fetch(ctx context.Context) ([]model.Content, error) {
+	ctx, cancel := context.WithTimeout(ctx, a.config.timeout())
+	defer cancel()
+
+	cmd := osexec.CommandContext(ctx, a.config.Command, a.config.Args...)
+	cmd.Dir = a.config.Dir
+	if len(a.config.Env) > 0 {
+		cmd.Env = os.Environ()
+		for key, value := range a.config.Env {
+			cmd.Env = append(cmd.Env, key+"="+value)
+		}
+	}
+	// children of the command that keep its output open don't hold the worker past the timeout
+	cmd.WaitDelay = 5 * time.Second
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	if ctx.Err() == context.DeadlineExceeded {
+		return nil, fmt.Errorf("command timed out after %s", a.config.timeout())
+	}
+	if err != nil {
+		var exitErr *osexec.ExitError
+		if errors.As(err, &exitErr) {
+			return nil, fmt.Errorf("command exited with %d, stderr: %s", exitErr.ExitCode(), tail(stderr.String(), maxStderrSize))
+		}
+		return nil, fmt.Errorf("failed to run command: %w", err)
+	}
+
+	return a.parseOutput(stdout.Bytes())
+}
+
+// parseOutput converts the JSON Lines output into contents, blank lines are skipped.
+// a line repeating the id of an earlier one replaces it, e.g. a script printing an item again once it's updated.
+func (a *Adapter) parseOutput(output []byte) ([]model.Content, error) {
+	var contents []model.Content
+	index := map[string]int{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	scanner.Buffer(nil, maxLineSize)
+	for line := 1; scanner.Scan(); line++ {
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+
+		var item jsonitem.Item
+		if err := json.Unmarshal(text, &item); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		content, err := item.Content(a.SourceID(), "Exec")
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if idx, ok := index[content.ID]; ok {
+			contents[idx] = content
+			continue
+		}
+		index[content.ID] = len(contents)
+		contents = append(contents, content)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read output: %w", err)
+	}
+
+	slices.SortStableFunc(contents, func(x, y model.Content) int {
+		return y.PublishedAt.Compare(x.PublishedAt)
+	})
+
+	return contents, nil
+}
+
+// tail returns the last n bytes of s
+func tail(s string, n int) string {
+	s = strings.TrimSpace(s)
+	if len(s) > n {
+		return "..." + s[len(s)-n:]
+	}
+	return s
+}
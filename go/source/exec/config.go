@@ -0,0 +1,57 @@
+package exec
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ryansiau/KeepUpdated/go/model"
+)
+
+// defaultTimeout is used when timeout is not configured
+const defaultTimeout = time.Minute
+
+// Config represents the configuration for a source running a command.
+// the command prints one JSON object per line to stdout, with the fields
+// id, title, url, author, description, published_at and metadata.
+type Config struct {
+	// Command is the path of the executable, looked up in PATH when it has no slash
+	Command string   `yaml:"command" mapstructure:"command"`
+	Args    []string `yaml:"args" mapstructure:"args"`
+	// Dir is the working directory of the command, the worker's by default
+	Dir string `yaml:"dir" mapstructure:"dir"`
+	// Env is added to the environment of the worker
+	Env map[string]string `yaml:"env" mapstructure:"env"`
+	// Timeout kills the command once it runs longer, e.g. "30s". one minute by default.
+	Timeout string `yaml:"timeout" mapstructure:"timeout"`
+}
+
+// Validate validates the exec source configuration
+func (c *Config) Validate() error {
+	if c.Command == "" {
+		return fmt.Errorf("command is required")
+	}
+	if c.Timeout != "" {
+		timeout, err := time.ParseDuration(c.Timeout)
+		if err != nil {
+			return fmt.Errorf("invalid timeout: %w", err)
+		}
+		if timeout <= 0 {
+			return fmt.Errorf("timeout has to be positive")
+		}
+	}
+	return nil
+}
+
+func (c *Config) IsCrawler() {}
+
+func (c *Config) Build(name string) (model.Source, error) {
+	return NewAdapter(c, name)
+}
+
+func (c *Config) timeout() time.Duration {
+	if c.Timeout == "" {
+		return defaultTimeout
+	}
+	timeout, _ := time.ParseDuration(c.Timeout)
+	return timeout
+}
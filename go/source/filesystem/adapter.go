@@ -0,0 +1,274 @@
+// Package filesystem notifies the files written to a local directory by other jobs, e.g. build artefacts and reports.
+package filesystem
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ryansiau/KeepUpdated/go/model"
+	"github.com/ryansiau/KeepUpdated/go/pkg/jsonitem"
+	generic_rss "github.com/ryansiau/KeepUpdated/go/source/generic-rss"
+)
+
+// Adapter implements the Source interface for a local directory
+type Adapter struct {
+	config *Config
+	name   string
+	path   string
+}
+
+var _ model.WatchingSource = (*Adapter)(nil)
+
+// NewAdapter creates a new filesystem adapter with configuration
+func NewAdapter(config *Config, name string) (*Adapter, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	path, err := filepath.Abs(config.Path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid path: %w", err)
+	}
+
+	if name == "" {
+		name = fmt.Sprintf("Filesystem: %s", filepath.Join(path, config.pattern()))
+	}
+
+	return &Adapter{
+		config: config,
+		name:   name,
+		path:   path,
+	}, nil
+}
+
+// Name returns the name of the source
+func (a *Adapter) Name() string {
+	return a.name
+}
+
+// Type returns the platform type
+func (a *Adapter) Type() string {
+	return "filesystem"
+}
+
+// SourceID returns the identifier of the source
+func (a *Adapter) SourceID() string {
+	id := fmt.Sprintf("Filesystem:%s:%s", a.path, a.config.pattern())
+	if a.config.Recursive {
+		id += ":recursive"
+	}
+	return id
+}
+
+// file is a file matching the pattern
+type file struct {
+	path string
+	rel  string
+	info fs.FileInfo
+}
+
+// Fetch lists the latest modified files. a file is a new content every time it's modified,
+// as the modification time is part of its id.
+func (a *Adapter) Fetch(ctx context.Context) ([]model.Content, error) {
+	files, err := a.list()
+	if err != nil {
+		return nil, err
+	}
+
+	var contents []model.Content
+	for _, f := range files {
+		if a.config.Parse && isParsable(f.path) {
+			items, err := a.parseFile(f)
+			if err != nil {
+				// the file may still be being written, it's parsed again once it's modified
+				logrus.WithField("source", a.name).Warnf("Skipping %s: %v", f.rel, err)
+				continue
+			}
+			contents = append(contents, items...)
+			continue
+		}
+
+		contents = append(contents, a.fileContent(f))
+	}
+
+	slices.SortStableFunc(contents, func(x, y model.Content) int {
+		return y.PublishedAt.Compare(x.PublishedAt)
+	})
+
+	return contents, nil
+}
+
+// list returns the latest modified files matching the pattern, newest first
+func (a *Adapter) list() ([]file, error) {
+	var files []file
+
+	err := filepath.WalkDir(a.path, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != a.path && !a.config.Recursive {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		if ok, _ := filepath.Match(a.config.pattern(), d.Name()); !ok {
+			return nil
+		}
+
+		info, err := d.Info()
+		if errors.Is(err, fs.ErrNotExist) {
+			// removed while listing
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		rel, _ := filepath.Rel(a.path, path)
+		files = append(files, file{path: path, rel: filepath.ToSlash(rel), info: info})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", a.path, err)
+	}
+
+	slices.SortFunc(files, func(x, y file) int {
+		return y.info.ModTime().Compare(x.info.ModTime())
+	})
+	if len(files) > a.config.maxFiles() {
+		files = files[:a.config.maxFiles()]
+	}
+
+	return files, nil
+}
+
+func (a *Adapter) fileContent(f file) model.Content {
+	modTime := f.info.ModTime()
+
+	return model.Content{
+		ID:          fmt.Sprintf("%s:%s@%d", a.SourceID(), f.rel, modTime.UnixNano()),
+		SourceID:    a.SourceID(),
+		Title:       f.rel,
+		Description: fmt.Sprintf("%s (%d bytes)", f.rel, f.info.Size()),
+		URL:         "file://" + filepath.ToSlash(f.path),
+		Platform:    "Filesystem",
+		PublishedAt: modTime,
+		UpdatedAt:   time.Now(),
+		Metadata:    f.metadata(),
+	}
+}
+
+// metadata describes the file for the metadata filter to match on
+func (f file) metadata() map[string]interface{} {
+	return map[string]interface{}{
+		"path":  f.path,
+		"size":  f.info.Size(),
+		"mtime": f.info.ModTime().Format(time.RFC3339),
+	}
+}
+
+func isParsable(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json", ".rss":
+		return true
+	}
+	return false
+}
+
+// parseFile reads the items of a .json or .rss file. the ids of the items are only unique within their file,
+// so they're namespaced by its path and a repeated id keeps the last item.
+func (a *Adapter) parseFile(f file) ([]model.Content, error) {
+	r, err := os.Open(f.path)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	var contents []model.Content
+	if strings.ToLower(filepath.Ext(f.path)) == ".rss" {
+		contents, err = generic_rss.ParseFeed(r, a.SourceID(), a.name)
+	} else {
+		contents, err = a.parseItems(r)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	index := map[string]int{}
+	var unique []model.Content
+	for _, content := range contents {
+		content.ID = a.SourceID() + ":" + f.rel + ":" + content.ID
+		content.Platform = "Filesystem"
+		if content.Metadata == nil {
+			content.Metadata = map[string]interface{}{}
+		}
+		for key, value := range f.metadata() {
+			if _, ok := content.Metadata[key]; !ok {
+				content.Metadata[key] = value
+			}
+		}
+
+		if idx, ok := index[content.ID]; ok {
+			unique[idx] = content
+			continue
+		}
+		index[content.ID] = len(unique)
+		unique = append(unique, content)
+	}
+
+	return unique, nil
+}
+
+// parseItems decodes items or arrays of items, one after another as in JSON Lines or as a single array.
+// the ids of the contents are the ids of the items, as with feed items.
+func (a *Adapter) parseItems(r io.Reader) ([]model.Content, error) {
+	var contents []model.Content
+	decoder := json.NewDecoder(r)
+	for {
+		var value json.RawMessage
+		err := decoder.Decode(&value)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var items []jsonitem.Item
+		if strings.HasPrefix(string(value), "[") {
+			err = json.Unmarshal(value, &items)
+		} else {
+			items = make([]jsonitem.Item, 1)
+			err = json.Unmarshal(value, &items[0])
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range items {
+			content, err := item.Content(a.SourceID(), "Filesystem")
+			if err != nil {
+				return nil, err
+			}
+			content.ID = item.ID
+			contents = append(contents, content)
+		}
+	}
+
+	return contents, nil
+}
@@ -0,0 +1,63 @@
+package filesystem
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/ryansiau/KeepUpdated/go/model"
+)
+
+// Defaults used when the fields aren't configured
+const (
+	defaultPattern  = "*"
+	defaultMaxFiles = 100
+)
+
+// Config represents the configuration for a source watching a local directory
+type Config struct {
+	// Path is the directory to watch
+	Path string `yaml:"path" mapstructure:"path"`
+	// Pattern is a glob matched against the file names, e.g. "*.tar.gz". every file by default.
+	Pattern string `yaml:"pattern" mapstructure:"pattern"`
+	// Recursive watches the subdirectories too
+	Recursive bool `yaml:"recursive" mapstructure:"recursive"`
+	// Parse reads the items of .json files, in the format of the exec source, and of .rss files
+	// instead of notifying the files themselves
+	Parse bool `yaml:"parse" mapstructure:"parse"`
+	// MaxFiles is the number of the latest modified files checked on every execution, 100 by default
+	MaxFiles int `yaml:"max_files" mapstructure:"max_files"`
+}
+
+// Validate validates the filesystem source configuration
+func (c *Config) Validate() error {
+	if c.Path == "" {
+		return fmt.Errorf("path is required")
+	}
+	if _, err := filepath.Match(c.pattern(), ""); err != nil {
+		return fmt.Errorf("invalid pattern: %w", err)
+	}
+	if c.MaxFiles < 0 {
+		return fmt.Errorf("max_files can't be negative")
+	}
+	return nil
+}
+
+func (c *Config) IsCrawler() {}
+
+func (c *Config) Build(name string) (model.Source, error) {
+	return NewAdapter(c, name)
+}
+
+func (c *Config) pattern() string {
+	if c.Pattern == "" {
+		return defaultPattern
+	}
+	return c.Pattern
+}
+
+func (c *Config) maxFiles() int {
+	if c.MaxFiles == 0 {
+		return defaultMaxFiles
+	}
+	return c.MaxFiles
+}
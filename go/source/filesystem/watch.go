@@ -0,0 +1,100 @@
+package filesystem
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/sirupsen/logrus"
+)
+
+// debounceDelay waits for the burst of events of a file being written to end before reporting a change
+const debounceDelay = 2 * time.Second
+
+// Watch watches the directory with inotify, or its platform's equivalent.
+// it fails on file systems that don't support it, e.g. network mounts, leaving the directory to be polled.
+func (a *Adapter) Watch(ctx context.Context, changed func()) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+
+	if err := a.addDirs(watcher, a.path); err != nil {
+		_ = watcher.Close()
+		return err
+	}
+
+	go func() {
+		defer watcher.Close()
+
+		var mu sync.Mutex
+		var timer *time.Timer
+		debounce := func() {
+			mu.Lock()
+			defer mu.Unlock()
+			if timer != nil {
+				timer.Stop()
+			}
+			timer = time.AfterFunc(debounceDelay, changed)
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				mu.Lock()
+				if timer != nil {
+					timer.Stop()
+				}
+				mu.Unlock()
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				// a file moved into the directory is created there
+				if !event.Has(fsnotify.Create) && !event.Has(fsnotify.Write) {
+					continue
+				}
+
+				// directories created later are watched too
+				if a.config.Recursive && event.Has(fsnotify.Create) {
+					if err := a.addDirs(watcher, event.Name); err != nil {
+						logrus.WithField("source", a.name).Warnf("Failed to watch %s: %v", event.Name, err)
+					}
+				}
+
+				if ok, _ := filepath.Match(a.config.pattern(), filepath.Base(event.Name)); ok {
+					debounce()
+				}
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				// e.g. an overflowing event queue, the interval polls catch up with what's missed
+				logrus.WithField("source", a.name).Warnf("Watch error: %v", err)
+			}
+		}
+	}()
+
+	return nil
+}
+
+// addDirs watches root, and every directory under it when the source is recursive
+func (a *Adapter) addDirs(watcher *fsnotify.Watcher, root string) error {
+	if !a.config.Recursive {
+		return watcher.Add(root)
+	}
+
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		return watcher.Add(path)
+	})
+}
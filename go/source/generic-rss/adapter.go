@@ -1,6 +1,7 @@
 package generic_rss
 
 import (
+	"bytes"
 	"context"
 	"encoding/xml"
 	"fmt"
@@ -20,10 +21,32 @@ type RSSFeed struct {
 }
 
 type Channel struct {
-	Title       string `xml:"title"`
-	Link        string `xml:"link"`
-	Description string `xml:"description"`
-	Items       []Item `xml:"item"`
+	Title       string         `xml:"title"`
+	Link        string         `xml:"link"`
+	Description string         `xml:"description"`
+	Images      []ChannelImage `xml:"image"` // Both <image> and the podcast artwork <itunes:image>
+	Items       []Item         `xml:"item"`
+}
+
+// ChannelImage is either an RSS <image> with its url child, or an <itunes:image> with its href attribute
+type ChannelImage struct {
+	URL  string `xml:"url"`
+	Href string `xml:"href,attr"`
+}
+
+// Artwork returns the image of the channel, preferring the podcast artwork
+func (c Channel) Artwork() string {
+	for _, image := range c.Images {
+		if image.Href != "" {
+			return strings.TrimSpace(image.Href)
+		}
+	}
+	for _, image := range c.Images {
+		if image.URL != "" {
+			return strings.TrimSpace(image.URL)
+		}
+	}
+	return ""
 }
 
 type Item struct {
@@ -35,6 +58,50 @@ type Item struct {
 	GUID        string `xml:"guid"`    // Unique identifier
 	Author      string `xml:"author"`  // Optional author field
 	Creator     string `xml:"creator"` // Dublin Core creator
+
+	// Podcast fields
+	Enclosure         *Enclosure     `xml:"enclosure"`
+	ITunesDuration    string         `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd duration"`
+	ITunesEpisode     string         `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd episode"`
+	ITunesSeason      string         `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd season"`
+	ITunesEpisodeType string         `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd episodeType"`
+	ITunesImage       ITunesImage    `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd image"`
+	MediaContents     []MediaContent `xml:"http://search.yahoo.com/mrss/ content"`
+	MediaThumbnail    ITunesImage    `xml:"http://search.yahoo.com/mrss/ thumbnail"`
+	MediaGroup        struct {
+		Contents  []MediaContent `xml:"http://search.yahoo.com/mrss/ content"`
+		Thumbnail ITunesImage    `xml:"http://search.yahoo.com/mrss/ thumbnail"`
+	} `xml:"http://search.yahoo.com/mrss/ group"`
+}
+
+// Enclosure is the media file attached to an item, e.g. the audio of a podcast episode
+type Enclosure struct {
+	URL    string `xml:"url,attr"`
+	Type   string `xml:"type,attr"`
+	Length string `xml:"length,attr"`
+}
+
+// ITunesImage is an image given by its href attribute, media:thumbnail uses url instead
+type ITunesImage struct {
+	Href string `xml:"href,attr"`
+	URL  string `xml:"url,attr"`
+}
+
+// Link returns the URL of the image
+func (i ITunesImage) Link() string {
+	if i.Href != "" {
+		return strings.TrimSpace(i.Href)
+	}
+	return strings.TrimSpace(i.URL)
+}
+
+// MediaContent is a media:content element of Media RSS
+type MediaContent struct {
+	URL      string `xml:"url,attr"`
+	Type     string `xml:"type,attr"`
+	Medium   string `xml:"medium,attr"`
+	FileSize string `xml:"fileSize,attr"`
+	Duration string `xml:"duration,attr"`
 }
 
 // Adapter implements the Source interface for RSS feeds
@@ -93,6 +160,11 @@ func (r *Adapter) Fetch(ctx context.Context) ([]model.Content, error) {
 
 // parseFeed parses the RSS feed and returns new content
 func (r *Adapter) parseFeed(reader io.Reader) ([]model.Content, error) {
+	return ParseFeed(reader, r.SourceID(), r.name)
+}
+
+// ParseFeed parses an RSS feed into the contents of the source, name is their platform and fallback author
+func ParseFeed(reader io.Reader, sourceID, name string) ([]model.Content, error) {
 	var feed RSSFeed
 	if err := xml.NewDecoder(reader).Decode(&feed); err != nil {
 		return nil, fmt.Errorf("failed to decode XML: %w", err)
@@ -108,7 +180,7 @@ func (r *Adapter) parseFeed(reader io.Reader) ([]model.Content, error) {
 		}
 
 		// Parse publication date
-		pubDate, err := parseDate(item.PubDate)
+		pubDate, err := ParseDate(item.PubDate)
 		if err != nil {
 			// If we can't parse the date, use the current time
 			pubDate = time.Now()
@@ -120,7 +192,7 @@ func (r *Adapter) parseFeed(reader io.Reader) ([]model.Content, error) {
 			author = item.Creator
 		}
 		if author == "" {
-			author = r.name // Fall back to source name
+			author = name // Fall back to source name
 		}
 
 		// Determine content text
@@ -131,15 +203,15 @@ func (r *Adapter) parseFeed(reader io.Reader) ([]model.Content, error) {
 
 		content := model.Content{
 			ID:          strings.TrimSpace(itemID),
-			SourceID:    r.SourceID(),
+			SourceID:    sourceID,
 			Title:       strings.TrimSpace(item.Title),
 			Description: strings.TrimSpace(contentText),
 			URL:         strings.TrimSpace(item.Link),
 			Author:      strings.TrimSpace(author),
-			Platform:    strings.TrimSpace(r.name),
+			Platform:    strings.TrimSpace(name),
 			PublishedAt: pubDate,
 			UpdatedAt:   time.Now(),
-			Metadata:    nil,
+			Metadata:    podcastMetadata(item, feed.Channel),
 		}
 
 		contents = append(contents, content)
@@ -152,8 +224,8 @@ func (r *Adapter) SourceID() string {
 	return fmt.Sprintf("RSS:%s", r.feedURL)
 }
 
-// parseDate attempts to parse various RSS date formats
-func parseDate(dateStr string) (time.Time, error) {
+// ParseDate attempts to parse various RSS date formats
+func ParseDate(dateStr string) (time.Time, error) {
 	// Try common RSS date formats
 	formats := []string{
 		time.RFC1123,  // "Mon, 02 Jan 2006 15:04:05 MST"
@@ -173,3 +245,19 @@ func parseDate(dateStr string) (time.Time, error) {
 
 	return time.Time{}, fmt.Errorf("unable to parse date: %s", dateStr)
 }
+
+var _ model.PushSource = (*Adapter)(nil)
+
+// Topic returns the URL of the feed, its hub is discovered from it
+func (r *Adapter) Topic(ctx context.Context) (string, string, error) {
+	return r.feedURL, "", nil
+}
+
+// ParsePush parses the pushed feed, which is the feed itself
+func (r *Adapter) ParsePush(ctx context.Context, body []byte) ([]model.Content, error) {
+	contents, err := r.parseFeed(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse pushed feed: %w", err)
+	}
+	return contents, nil
+}
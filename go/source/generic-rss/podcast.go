@@ -0,0 +1,116 @@
+package generic_rss
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/ryansiau/KeepUpdated/go/model"
+)
+
+// podcastMetadata collects the enclosure, the iTunes tags and the Media RSS content of an item.
+// it returns nil for items without any of them, like regular feeds always did.
+func podcastMetadata(item Item, channel Channel) model.Metadata {
+	metadata := model.Metadata{}
+
+	enclosure := item.Enclosure
+	if enclosure == nil || enclosure.URL == "" {
+		enclosure = mediaEnclosure(item)
+	}
+	if enclosure != nil && enclosure.URL != "" {
+		metadata["enclosure_url"] = strings.TrimSpace(enclosure.URL)
+		metadata["enclosure_type"] = strings.TrimSpace(enclosure.Type)
+		if size, err := strconv.ParseInt(strings.TrimSpace(enclosure.Length), 10, 64); err == nil && size > 0 {
+			metadata["enclosure_size"] = size
+		}
+	}
+
+	duration := item.ITunesDuration
+	if duration == "" {
+		for _, content := range allMediaContents(item) {
+			if content.Duration != "" {
+				duration = content.Duration
+				break
+			}
+		}
+	}
+	if seconds, ok := parseDuration(duration); ok {
+		metadata["duration"] = seconds
+	}
+
+	if episode, err := strconv.Atoi(strings.TrimSpace(item.ITunesEpisode)); err == nil {
+		metadata["episode"] = episode
+	}
+	if season, err := strconv.Atoi(strings.TrimSpace(item.ITunesSeason)); err == nil {
+		metadata["season"] = season
+	}
+	if episodeType := strings.TrimSpace(item.ITunesEpisodeType); episodeType != "" {
+		metadata["episode_type"] = episodeType
+	}
+
+	// the artwork of the episode, falling back to the show's for items that are podcast episodes
+	thumbnail := item.ITunesImage.Link()
+	if thumbnail == "" {
+		thumbnail = item.MediaThumbnail.Link()
+	}
+	if thumbnail == "" {
+		thumbnail = item.MediaGroup.Thumbnail.Link()
+	}
+	if thumbnail == "" {
+		for _, content := range allMediaContents(item) {
+			if content.Medium == "image" || strings.HasPrefix(content.Type, "image/") {
+				thumbnail = strings.TrimSpace(content.URL)
+				break
+			}
+		}
+	}
+	if thumbnail == "" && len(metadata) > 0 {
+		thumbnail = channel.Artwork()
+	}
+	if thumbnail != "" {
+		metadata[model.MetadataThumbnail] = thumbnail
+	}
+
+	if len(metadata) == 0 {
+		return nil
+	}
+	return metadata
+}
+
+// mediaEnclosure picks the first audio or video media:content, for feeds without an enclosure
+func mediaEnclosure(item Item) *Enclosure {
+	for _, content := range allMediaContents(item) {
+		isMedia := content.Medium == "audio" || content.Medium == "video" ||
+			strings.HasPrefix(content.Type, "audio/") || strings.HasPrefix(content.Type, "video/")
+		if isMedia && content.URL != "" {
+			return &Enclosure{
+				URL:    content.URL,
+				Type:   content.Type,
+				Length: content.FileSize,
+			}
+		}
+	}
+	return nil
+}
+
+func allMediaContents(item Item) []MediaContent {
+	return append(append([]MediaContent{}, item.MediaContents...), item.MediaGroup.Contents...)
+}
+
+// parseDuration converts an iTunes duration into seconds. it can be given as seconds, MM:SS or HH:MM:SS.
+func parseDuration(duration string) (int, bool) {
+	duration = strings.TrimSpace(duration)
+	if duration == "" {
+		return 0, false
+	}
+
+	seconds := 0
+	for _, part := range strings.Split(duration, ":") {
+		// some feeds write fractions of seconds
+		value, err := strconv.ParseFloat(part, 64)
+		if err != nil || value < 0 {
+			return 0, false
+		}
+		seconds = seconds*60 + int(value)
+	}
+	return seconds, true
+}
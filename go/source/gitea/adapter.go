@@ -0,0 +1,99 @@
+package gitea
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"resty.dev/v3"
+
+	"github.com/ryansiau/KeepUpdated/go/common"
+	"github.com/ryansiau/KeepUpdated/go/model"
+)
+
+// Adapter implements the Source interface for Gitea and Forgejo repositories
+type Adapter struct {
+	client *resty.Client
+	config *Config
+	name   string
+	host   string
+}
+
+// NewAdapter creates a new Gitea adapter with configuration
+func NewAdapter(config *Config, name string) (*Adapter, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	u, err := url.Parse(config.baseURL())
+	if err != nil {
+		return nil, err
+	}
+
+	if name == "" {
+		name = fmt.Sprintf("Gitea: %s %s", config.Repository, config.mode())
+	}
+
+	client := resty.New().
+		SetTimeout(10*time.Second).
+		SetBaseURL(config.baseURL()+"/api/v1").
+		SetHeader("User-Agent", common.HTTPClientUserAgent)
+	if config.Token != "" {
+		client.SetHeader("Authorization", "token "+config.Token)
+	}
+
+	return &Adapter{
+		client: client,
+		config: config,
+		name:   name,
+		host:   u.Host,
+	}, nil
+}
+
+// Name returns the name of the source
+func (a *Adapter) Name() string {
+	return a.name
+}
+
+// Type returns the platform type
+func (a *Adapter) Type() string {
+	return "gitea"
+}
+
+// SourceID returns the identifier of the source
+func (a *Adapter) SourceID() string {
+	return fmt.Sprintf("Gitea:%s/%s:%s", a.host, strings.ToLower(a.config.Repository), a.config.mode())
+}
+
+// Fetch retrieves the latest releases or tags of the repository
+func (a *Adapter) Fetch(ctx context.Context) ([]model.Content, error) {
+	switch a.config.mode() {
+	case ModeTags:
+		return a.fetchTags(ctx)
+	default:
+		return a.fetchReleases(ctx)
+	}
+}
+
+func (a *Adapter) get(ctx context.Context, path string, result interface{}) error {
+	resp, err := a.client.R().
+		SetContext(ctx).
+		SetResult(result).
+		Get(path)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode() != 200 {
+		return fmt.Errorf("status code: %d, body: %s", resp.StatusCode(), resp.String())
+	}
+
+	return nil
+}
+
+// contentID builds an id unique across instances, repositories and modes, e.g. codeberg.org/owner/repo/releases/v1.0.0
+func (a *Adapter) contentID(key string) string {
+	return fmt.Sprintf("%s/%s/%s/%s", a.host, strings.ToLower(a.config.Repository), a.config.mode(), key)
+}
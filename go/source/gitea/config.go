@@ -0,0 +1,75 @@
+package gitea
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/ryansiau/KeepUpdated/go/model"
+)
+
+// Fetch modes
+const (
+	ModeReleases = "releases"
+	ModeTags     = "tags"
+)
+
+var validModes = []string{ModeReleases, ModeTags}
+
+var repositoryRegex = regexp.MustCompile(`^[A-Za-z0-9._-]+/[A-Za-z0-9._-]+$`)
+
+// Config represents the configuration for a Gitea or Forgejo source, e.g. Codeberg
+type Config struct {
+	// BaseURL of the instance, e.g. https://codeberg.org
+	BaseURL string `yaml:"base_url" mapstructure:"base_url"`
+	// Repository in the owner/repo format
+	Repository string `yaml:"repository" mapstructure:"repository"`
+	// Mode defaults to "releases"
+	Mode string `yaml:"mode" mapstructure:"mode"`
+	// IncludePrereleases includes releases marked as pre-release. Drafts are never included.
+	IncludePrereleases bool `yaml:"include_prereleases" mapstructure:"include_prereleases"`
+	// Token is an access token, needed for private repositories
+	Token string `yaml:"token" mapstructure:"token"`
+}
+
+// Validate validates the Gitea source configuration
+func (c *Config) Validate() error {
+	if c.BaseURL == "" {
+		return fmt.Errorf("base_url is required")
+	}
+	u, err := url.Parse(c.BaseURL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("invalid base_url: %s", c.BaseURL)
+	}
+	if c.Repository == "" {
+		return fmt.Errorf("repository is required")
+	}
+	if !repositoryRegex.MatchString(c.Repository) {
+		return fmt.Errorf("repository has to be in the owner/repo format: %s", c.Repository)
+	}
+	if c.Mode != "" && !slices.Contains(validModes, c.Mode) {
+		return fmt.Errorf("invalid mode: %s", c.Mode)
+	}
+	return nil
+}
+
+func (c *Config) IsCrawler() {}
+
+func (c *Config) Build(name string) (model.Source, error) {
+	return NewAdapter(c, name)
+}
+
+// mode returns the configured mode, defaulting to releases
+func (c *Config) mode() string {
+	if c.Mode == "" {
+		return ModeReleases
+	}
+	return c.Mode
+}
+
+// baseURL returns the configured base URL without trailing slash
+func (c *Config) baseURL() string {
+	return strings.TrimSuffix(c.BaseURL, "/")
+}
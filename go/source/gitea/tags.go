@@ -0,0 +1,48 @@
+package gitea
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/ryansiau/KeepUpdated/go/model"
+	"github.com/ryansiau/KeepUpdated/go/pkg/forge"
+)
+
+type Tag struct {
+	Name    string `json:"name"`
+	Message string `json:"message"`
+	Commit  struct {
+		SHA     string    `json:"sha"`
+		Created time.Time `json:"created"`
+	} `json:"commit"`
+}
+
+func (a *Adapter) FetchTags(ctx context.Context) ([]Tag, error) {
+	var tags []Tag
+	err := a.get(ctx, fmt.Sprintf("/repos/%s/tags?limit=30", a.config.Repository), &tags)
+	return tags, err
+}
+
+func (a *Adapter) fetchTags(ctx context.Context) ([]model.Content, error) {
+	tags, err := a.FetchTags(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var contents []model.Content
+	for _, tag := range tags {
+		contents = append(contents, forge.Tag{
+			Repository:  a.config.Repository,
+			Name:        tag.Name,
+			Message:     tag.Message,
+			URL:         fmt.Sprintf("%s/%s/src/tag/%s", a.config.baseURL(), a.config.Repository, url.PathEscape(tag.Name)),
+			Author:      a.config.Repository,
+			CommitSHA:   tag.Commit.SHA,
+			PublishedAt: tag.Commit.Created,
+		}.Content(a.contentID(tag.Name), a.SourceID(), "Gitea"))
+	}
+
+	return contents, nil
+}
@@ -0,0 +1,150 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"resty.dev/v3"
+
+	"github.com/ryansiau/KeepUpdated/go/common"
+	"github.com/ryansiau/KeepUpdated/go/model"
+)
+
+// apiBaseURL is a variable so a local stand-in server can take its place
+var apiBaseURL = "https://api.github.com"
+
+// stateETag keeps the ETag of the last response, unchanged listings are answered with a 304
+// which doesn't count against the rate limit
+const stateETag = "etag"
+
+// Adapter implements the Source interface for GitHub repositories
+type Adapter struct {
+	client *resty.Client
+	config *Config
+	name   string
+
+	etag string
+}
+
+var _ model.StatefulSource = (*Adapter)(nil)
+
+// NewAdapter creates a new GitHub adapter with configuration
+func NewAdapter(config *Config, name string) (*Adapter, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	if name == "" {
+		name = fmt.Sprintf("GitHub: %s %s", config.Repository, config.mode())
+	}
+
+	client := resty.New().
+		SetTimeout(10*time.Second).
+		SetBaseURL(apiBaseURL).
+		SetHeader("User-Agent", common.HTTPClientUserAgent).
+		SetHeader("Accept", "application/vnd.github+json").
+		SetHeader("X-GitHub-Api-Version", "2022-11-28")
+	if config.Token != "" {
+		client.SetAuthToken(config.Token)
+	}
+
+	return &Adapter{
+		client: client,
+		config: config,
+		name:   name,
+	}, nil
+}
+
+// Name returns the name of the source
+func (a *Adapter) Name() string {
+	return a.name
+}
+
+// Type returns the platform type
+func (a *Adapter) Type() string {
+	return "github"
+}
+
+// SourceID returns the identifier of the source.
+// the filters are part of it, so workflows watching the same repository with different filters are tracked separately.
+func (a *Adapter) SourceID() string {
+	id := fmt.Sprintf("GitHub:%s:%s", strings.ToLower(a.config.Repository), a.config.mode())
+
+	var filters []string
+	if len(a.config.Labels) > 0 {
+		filters = append(filters, "labels="+strings.Join(a.config.Labels, ","))
+	}
+	if a.config.Author != "" {
+		filters = append(filters, "author="+a.config.Author)
+	}
+	if a.config.State != "" {
+		filters = append(filters, "state="+a.config.State)
+	}
+	if len(filters) > 0 {
+		id += ":" + strings.Join(filters, ";")
+	}
+
+	return id
+}
+
+// LoadState restores the ETag of the last response
+func (a *Adapter) LoadState(state map[string]string) {
+	a.etag = state[stateETag]
+}
+
+// State returns the ETag of the last response
+func (a *Adapter) State() map[string]string {
+	return map[string]string{
+		stateETag: a.etag,
+	}
+}
+
+// Fetch retrieves the latest releases or tags of the repository
+func (a *Adapter) Fetch(ctx context.Context) ([]model.Content, error) {
+	switch a.config.mode() {
+	case ModeTags:
+		return a.fetchTags(ctx)
+	case ModeIssues, ModePullRequests:
+		return a.fetchIssues(ctx)
+	case ModeDiscussions:
+		return a.fetchDiscussions(ctx)
+	default:
+		return a.fetchReleases(ctx)
+	}
+}
+
+// get requests path with the stored ETag. notModified is true when the response is a 304.
+func (a *Adapter) get(ctx context.Context, path string, result interface{}) (notModified bool, err error) {
+	req := a.client.R().
+		SetContext(ctx).
+		SetResult(result)
+	if a.etag != "" {
+		req.SetHeader("If-None-Match", a.etag)
+	}
+
+	resp, err := req.Get(path)
+	if err != nil {
+		return false, err
+	}
+
+	if resp.StatusCode() == http.StatusNotModified {
+		return true, nil
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return false, fmt.Errorf("status code: %d, body: %s", resp.StatusCode(), resp.String())
+	}
+
+	a.etag = resp.Header().Get("ETag")
+
+	return false, nil
+}
+
+// contentID namespaces the key by the source, e.g. GitHub:owner/repo:releases:v1.0.0.
+// workflows on the same repository with different filters can list the same issue.
+func (a *Adapter) contentID(key string) string {
+	return a.SourceID() + ":" + key
+}
@@ -0,0 +1,172 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/ryansiau/KeepUpdated/go/model"
+)
+
+const releases = `[
+	{"tag_name": "v2.0.0-rc.1", "name": "", "prerelease": true, "published_at": "2026-05-03T00:00:00Z"},
+	{"tag_name": "v2.0.0", "name": "Two", "draft": true},
+	{"tag_name": "v1.1.0", "name": "One dot one", "html_url": "https://github.com/owner/repo/releases/tag/v1.1.0",
+		"published_at": "2026-05-02T00:00:00Z", "author": {"login": "gopher"}},
+	{"tag_name": "v1.0.0", "name": "", "created_at": "2026-05-01T00:00:00Z"}
+]`
+
+// releasesETag is the ETag of the listing, requests sending it are answered with a 304
+const releasesETag = `"releases-1"`
+
+// standIn serves the releases of owner/repo
+type standIn struct {
+	requests int
+	// ifNoneMatch is the If-None-Match header of the last request
+	ifNoneMatch string
+}
+
+func newStandIn(t *testing.T) *standIn {
+	t.Helper()
+
+	s := &standIn{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/owner/repo/releases" {
+			http.NotFound(w, r)
+			return
+		}
+
+		s.requests++
+		s.ifNoneMatch = r.Header.Get("If-None-Match")
+		if s.ifNoneMatch == releasesETag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", releasesETag)
+		_, _ = w.Write([]byte(releases))
+	}))
+	t.Cleanup(server.Close)
+
+	previousAPIBaseURL := apiBaseURL
+	apiBaseURL = server.URL
+	t.Cleanup(func() {
+		apiBaseURL = previousAPIBaseURL
+	})
+
+	return s
+}
+
+func ids(contents []model.Content) []string {
+	var ids []string
+	for _, content := range contents {
+		ids = append(ids, content.ID)
+	}
+	return ids
+}
+
+func TestReleases(t *testing.T) {
+	newStandIn(t)
+
+	adapter, err := NewAdapter(&Config{Repository: "owner/repo"}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	contents, err := adapter.Fetch(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"GitHub:owner/repo:releases:v1.1.0", "GitHub:owner/repo:releases:v1.0.0"}
+	if got := ids(contents); !slices.Equal(got, want) {
+		t.Fatalf("IDs = %v, want the releases without the draft and the pre-release %v", got, want)
+	}
+	if contents[0].Title != "owner/repo One dot one" || contents[0].Author != "gopher" || contents[0].Platform != "GitHub" {
+		t.Errorf("unexpected content: %+v", contents[0])
+	}
+	// releases without a name are titled with their tag, and dated with their creation when unpublished
+	if contents[1].Title != "owner/repo v1.0.0" || contents[1].PublishedAt.Day() != 1 {
+		t.Errorf("unexpected content: %+v", contents[1])
+	}
+
+	adapter, err = NewAdapter(&Config{Repository: "owner/repo", IncludePrereleases: true}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	contents, err = adapter.Fetch(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(contents) != 3 || contents[0].ID != "GitHub:owner/repo:releases:v2.0.0-rc.1" {
+		t.Errorf("IDs = %v, want the pre-release included, but still not the draft", ids(contents))
+	}
+}
+
+func TestETag(t *testing.T) {
+	s := newStandIn(t)
+
+	adapter, err := NewAdapter(&Config{Repository: "owner/repo"}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := adapter.Fetch(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if s.ifNoneMatch != "" {
+		t.Errorf("first request sent If-None-Match %q", s.ifNoneMatch)
+	}
+
+	contents, err := adapter.Fetch(context.Background())
+	if err != nil || contents != nil {
+		t.Fatalf("got %v, %v for an unchanged listing, want nothing", ids(contents), err)
+	}
+	if s.ifNoneMatch != releasesETag {
+		t.Errorf("second request sent If-None-Match %q, want the ETag of the first response", s.ifNoneMatch)
+	}
+
+	// the ETag outlives the adapter through the state, and a 304 keeps it
+	restored, err := NewAdapter(&Config{Repository: "owner/repo"}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	restored.LoadState(adapter.State())
+	if contents, err := restored.Fetch(context.Background()); err != nil || contents != nil {
+		t.Fatalf("got %v, %v with the restored ETag, want nothing", ids(contents), err)
+	}
+	if s.requests != 3 || restored.State()[stateETag] != releasesETag {
+		t.Errorf("sent %d requests, state %v", s.requests, restored.State())
+	}
+}
+
+func TestContentIDNamespace(t *testing.T) {
+	configs := []*Config{
+		{Repository: "owner/repo", Mode: ModeIssues},
+		{Repository: "owner/repo", Mode: ModeIssues, Labels: []string{"bug"}},
+		{Repository: "owner/repo", Mode: ModePullRequests},
+		{Repository: "Owner/Repo", Mode: ModeIssues, State: "closed"},
+	}
+
+	var got []string
+	for _, config := range configs {
+		adapter, err := NewAdapter(config, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, adapter.contentID("42"))
+	}
+
+	want := []string{
+		"GitHub:owner/repo:issues:42",
+		"GitHub:owner/repo:issues:labels=bug:42",
+		"GitHub:owner/repo:pull_requests:42",
+		"GitHub:owner/repo:issues:state=closed:42",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("IDs = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,92 @@
+package github
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+
+	"github.com/ryansiau/KeepUpdated/go/model"
+)
+
+// Fetch modes
+const (
+	ModeReleases     = "releases"
+	ModeTags         = "tags"
+	ModeIssues       = "issues"
+	ModePullRequests = "pull_requests"
+	ModeDiscussions  = "discussions"
+)
+
+var (
+	validModes  = []string{ModeReleases, ModeTags, ModeIssues, ModePullRequests, ModeDiscussions}
+	validStates = []string{"open", "closed", "all"}
+)
+
+var repositoryRegex = regexp.MustCompile(`^[A-Za-z0-9-]+/[A-Za-z0-9._-]+$`)
+
+// Config represents the configuration for a GitHub source
+type Config struct {
+	Repository string `yaml:"repository" mapstructure:"repository"` // owner/repo
+	// Mode defaults to "releases"
+	Mode string `yaml:"mode" mapstructure:"mode"`
+	// IncludePrereleases includes releases marked as pre-release. Drafts are never included.
+	IncludePrereleases bool `yaml:"include_prereleases" mapstructure:"include_prereleases"`
+	// Token is optional, but raises the rate limit from 60 to 5000 requests per hour.
+	// It is required by the discussions mode, as discussions are only exposed through GraphQL.
+	Token string `yaml:"token" mapstructure:"token"`
+
+	// Labels, Author and State narrow down the issues, pull requests and discussions.
+	// Items need every label to match. State defaults to "open".
+	Labels []string `yaml:"labels" mapstructure:"labels"`
+	Author string   `yaml:"author" mapstructure:"author"`
+	State  string   `yaml:"state" mapstructure:"state"`
+}
+
+// Validate validates the GitHub source configuration
+func (c *Config) Validate() error {
+	if c.Repository == "" {
+		return fmt.Errorf("repository is required")
+	}
+	if !repositoryRegex.MatchString(c.Repository) {
+		return fmt.Errorf("repository has to be in the owner/repo format: %s", c.Repository)
+	}
+	if c.Mode != "" && !slices.Contains(validModes, c.Mode) {
+		return fmt.Errorf("invalid mode: %s", c.Mode)
+	}
+	if c.State != "" && !slices.Contains(validStates, c.State) {
+		return fmt.Errorf("invalid state: %s", c.State)
+	}
+
+	mode := c.mode()
+	if mode == ModeReleases || mode == ModeTags {
+		if len(c.Labels) > 0 || c.Author != "" || c.State != "" {
+			return fmt.Errorf("labels, author and state are not supported in %s mode", mode)
+		}
+	}
+	if mode == ModeDiscussions && c.Token == "" {
+		return fmt.Errorf("token is required in discussions mode")
+	}
+	return nil
+}
+
+func (c *Config) IsCrawler() {}
+
+func (c *Config) Build(name string) (model.Source, error) {
+	return NewAdapter(c, name)
+}
+
+// mode returns the configured mode, defaulting to releases
+func (c *Config) mode() string {
+	if c.Mode == "" {
+		return ModeReleases
+	}
+	return c.Mode
+}
+
+// state returns the configured state, defaulting to open
+func (c *Config) state() string {
+	if c.State == "" {
+		return "open"
+	}
+	return c.State
+}
@@ -0,0 +1,162 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ryansiau/KeepUpdated/go/model"
+)
+
+const discussionsQuery = `query($owner: String!, $name: String!, $states: [DiscussionState!]) {
+  repository(owner: $owner, name: $name) {
+    discussions(first: 50, orderBy: {field: CREATED_AT, direction: DESC}, states: $states) {
+      nodes {
+        number
+        title
+        body
+        url
+        closed
+        createdAt
+        authorAssociation
+        author { login }
+        category { name }
+        labels(first: 20) { nodes { name } }
+        comments { totalCount }
+      }
+    }
+  }
+}`
+
+type Discussion struct {
+	Number            int       `json:"number"`
+	Title             string    `json:"title"`
+	Body              string    `json:"body"`
+	URL               string    `json:"url"`
+	Closed            bool      `json:"closed"`
+	CreatedAt         time.Time `json:"createdAt"`
+	AuthorAssociation string    `json:"authorAssociation"`
+	Author            struct {
+		Login string `json:"login"`
+	} `json:"author"`
+	Category struct {
+		Name string `json:"name"`
+	} `json:"category"`
+	Labels struct {
+		Nodes []struct {
+			Name string `json:"name"`
+		} `json:"nodes"`
+	} `json:"labels"`
+	Comments struct {
+		TotalCount int `json:"totalCount"`
+	} `json:"comments"`
+}
+
+// FetchDiscussions lists the latest discussions through the GraphQL API
+func (a *Adapter) FetchDiscussions(ctx context.Context) ([]Discussion, error) {
+	owner, name, _ := strings.Cut(a.config.Repository, "/")
+
+	variables := map[string]interface{}{
+		"owner": owner,
+		"name":  name,
+	}
+	switch a.config.state() {
+	case "open":
+		variables["states"] = []string{"OPEN"}
+	case "closed":
+		variables["states"] = []string{"CLOSED"}
+	}
+
+	var res struct {
+		Data struct {
+			Repository struct {
+				Discussions struct {
+					Nodes []Discussion `json:"nodes"`
+				} `json:"discussions"`
+			} `json:"repository"`
+		} `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+
+	resp, err := a.client.R().
+		SetContext(ctx).
+		SetBody(map[string]interface{}{
+			"query":     discussionsQuery,
+			"variables": variables,
+		}).
+		SetResult(&res).
+		Post("/graphql")
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode() != 200 {
+		return nil, fmt.Errorf("status code: %d, body: %s", resp.StatusCode(), resp.String())
+	}
+	if len(res.Errors) > 0 {
+		return nil, fmt.Errorf("graphql error: %s", res.Errors[0].Message)
+	}
+
+	return res.Data.Repository.Discussions.Nodes, nil
+}
+
+func (a *Adapter) fetchDiscussions(ctx context.Context) ([]model.Content, error) {
+	discussions, err := a.FetchDiscussions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var contents []model.Content
+	for _, discussion := range discussions {
+		// GraphQL can't filter discussions by labels or author
+		if a.config.Author != "" && !strings.EqualFold(discussion.Author.Login, a.config.Author) {
+			continue
+		}
+
+		var labels []string
+		for _, label := range discussion.Labels.Nodes {
+			labels = append(labels, label.Name)
+		}
+
+		hasLabels := true
+		for _, label := range a.config.Labels {
+			hasLabels = hasLabels && slices.Contains(labels, label)
+		}
+		if !hasLabels {
+			continue
+		}
+
+		state := "open"
+		if discussion.Closed {
+			state = "closed"
+		}
+
+		contents = append(contents, model.Content{
+			ID:          a.contentID(strconv.Itoa(discussion.Number)),
+			SourceID:    a.SourceID(),
+			Title:       fmt.Sprintf("%s#%d: %s", a.config.Repository, discussion.Number, discussion.Title),
+			Description: discussion.Body,
+			URL:         discussion.URL,
+			Author:      discussion.Author.Login,
+			Platform:    "GitHub",
+			PublishedAt: discussion.CreatedAt,
+			UpdatedAt:   time.Now(),
+			Metadata: map[string]interface{}{
+				"repository":         a.config.Repository,
+				"number":             discussion.Number,
+				"state":              state,
+				"labels":             strings.Join(labels, ","),
+				"comments":           discussion.Comments.TotalCount,
+				"author_association": discussion.AuthorAssociation,
+				"category":           discussion.Category.Name,
+			},
+		})
+	}
+
+	return contents, nil
+}
@@ -0,0 +1,102 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ryansiau/KeepUpdated/go/model"
+)
+
+type Issue struct {
+	Number            int       `json:"number"`
+	Title             string    `json:"title"`
+	Body              string    `json:"body"`
+	HTMLURL           string    `json:"html_url"`
+	State             string    `json:"state"`
+	Comments          int       `json:"comments"`
+	AuthorAssociation string    `json:"author_association"`
+	Draft             bool      `json:"draft"`
+	CreatedAt         time.Time `json:"created_at"`
+	User              struct {
+		Login string `json:"login"`
+	} `json:"user"`
+	Labels []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+	// PullRequest is only set when the issue is a pull request
+	PullRequest *struct {
+		MergedAt *time.Time `json:"merged_at"`
+	} `json:"pull_request"`
+}
+
+// FetchIssues lists the latest issues and pull requests, the issues endpoint returning both
+func (a *Adapter) FetchIssues(ctx context.Context) ([]Issue, bool, error) {
+	params := url.Values{}
+	params.Set("state", a.config.state())
+	params.Set("sort", "created")
+	params.Set("direction", "desc")
+	params.Set("per_page", "100")
+	if len(a.config.Labels) > 0 {
+		params.Set("labels", strings.Join(a.config.Labels, ","))
+	}
+	if a.config.Author != "" {
+		params.Set("creator", a.config.Author)
+	}
+
+	var issues []Issue
+	notModified, err := a.get(ctx, fmt.Sprintf("/repos/%s/issues?%s", a.config.Repository, params.Encode()), &issues)
+	return issues, notModified, err
+}
+
+func (a *Adapter) fetchIssues(ctx context.Context) ([]model.Content, error) {
+	issues, notModified, err := a.FetchIssues(ctx)
+	if err != nil || notModified {
+		return nil, err
+	}
+
+	wantPullRequests := a.config.mode() == ModePullRequests
+
+	var contents []model.Content
+	for _, issue := range issues {
+		if (issue.PullRequest != nil) != wantPullRequests {
+			continue
+		}
+
+		var labels []string
+		for _, label := range issue.Labels {
+			labels = append(labels, label.Name)
+		}
+
+		metadata := map[string]interface{}{
+			"repository":         a.config.Repository,
+			"number":             issue.Number,
+			"state":              issue.State,
+			"labels":             strings.Join(labels, ","),
+			"comments":           issue.Comments,
+			"author_association": issue.AuthorAssociation,
+		}
+		if issue.PullRequest != nil {
+			metadata["draft"] = issue.Draft
+			metadata["merged"] = issue.PullRequest.MergedAt != nil
+		}
+
+		contents = append(contents, model.Content{
+			ID:          a.contentID(strconv.Itoa(issue.Number)),
+			SourceID:    a.SourceID(),
+			Title:       fmt.Sprintf("%s#%d: %s", a.config.Repository, issue.Number, issue.Title),
+			Description: issue.Body,
+			URL:         issue.HTMLURL,
+			Author:      issue.User.Login,
+			Platform:    "GitHub",
+			PublishedAt: issue.CreatedAt,
+			UpdatedAt:   time.Now(),
+			Metadata:    metadata,
+		})
+	}
+
+	return contents, nil
+}
@@ -0,0 +1,66 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ryansiau/KeepUpdated/go/model"
+	"github.com/ryansiau/KeepUpdated/go/pkg/forge"
+)
+
+type Release struct {
+	ID          int64     `json:"id"`
+	TagName     string    `json:"tag_name"`
+	Name        string    `json:"name"`
+	Body        string    `json:"body"`
+	HTMLURL     string    `json:"html_url"`
+	Draft       bool      `json:"draft"`
+	Prerelease  bool      `json:"prerelease"`
+	CreatedAt   time.Time `json:"created_at"`
+	PublishedAt time.Time `json:"published_at"`
+	Author      struct {
+		Login string `json:"login"`
+	} `json:"author"`
+}
+
+func (a *Adapter) FetchReleases(ctx context.Context) ([]Release, bool, error) {
+	var releases []Release
+	notModified, err := a.get(ctx, fmt.Sprintf("/repos/%s/releases?per_page=30", a.config.Repository), &releases)
+	return releases, notModified, err
+}
+
+func (a *Adapter) fetchReleases(ctx context.Context) ([]model.Content, error) {
+	releases, notModified, err := a.FetchReleases(ctx)
+	if err != nil || notModified {
+		return nil, err
+	}
+
+	var contents []model.Content
+	for _, release := range releases {
+		if release.Draft {
+			continue
+		}
+		if release.Prerelease && !a.config.IncludePrereleases {
+			continue
+		}
+
+		publishedAt := release.PublishedAt
+		if publishedAt.IsZero() {
+			publishedAt = release.CreatedAt
+		}
+
+		contents = append(contents, forge.Release{
+			Repository:  a.config.Repository,
+			TagName:     release.TagName,
+			Name:        release.Name,
+			Body:        release.Body,
+			URL:         release.HTMLURL,
+			Author:      release.Author.Login,
+			Prerelease:  release.Prerelease,
+			PublishedAt: publishedAt,
+		}.Content(a.contentID(release.TagName), a.SourceID(), "GitHub"))
+	}
+
+	return contents, nil
+}
@@ -0,0 +1,43 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ryansiau/KeepUpdated/go/model"
+	"github.com/ryansiau/KeepUpdated/go/pkg/forge"
+)
+
+type Tag struct {
+	Name   string `json:"name"`
+	Commit struct {
+		SHA string `json:"sha"`
+	} `json:"commit"`
+}
+
+func (a *Adapter) FetchTags(ctx context.Context) ([]Tag, bool, error) {
+	var tags []Tag
+	notModified, err := a.get(ctx, fmt.Sprintf("/repos/%s/tags?per_page=30", a.config.Repository), &tags)
+	return tags, notModified, err
+}
+
+func (a *Adapter) fetchTags(ctx context.Context) ([]model.Content, error) {
+	tags, notModified, err := a.FetchTags(ctx)
+	if err != nil || notModified {
+		return nil, err
+	}
+
+	// the tags endpoint doesn't expose any date, forge.Tag falls back to the time of discovery
+	var contents []model.Content
+	for _, tag := range tags {
+		contents = append(contents, forge.Tag{
+			Repository: a.config.Repository,
+			Name:       tag.Name,
+			URL:        fmt.Sprintf("https://github.com/%s/releases/tag/%s", a.config.Repository, tag.Name),
+			Author:     a.config.Repository,
+			CommitSHA:  tag.Commit.SHA,
+		}.Content(a.contentID(tag.Name), a.SourceID(), "GitHub"))
+	}
+
+	return contents, nil
+}
@@ -0,0 +1,75 @@
+package gitlab
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/ryansiau/KeepUpdated/go/model"
+)
+
+// Fetch modes
+const (
+	ModeReleases = "releases"
+	ModeTags     = "tags"
+)
+
+var validModes = []string{ModeReleases, ModeTags}
+
+// defaultBaseURL is used when base_url is not configured
+const defaultBaseURL = "https://gitlab.com"
+
+// Config represents the configuration for a GitLab source
+type Config struct {
+	// BaseURL of the GitLab instance, defaults to https://gitlab.com
+	BaseURL string `yaml:"base_url" mapstructure:"base_url"`
+	// Project is the full path of the project, e.g. gitlab-org/gitlab-runner
+	Project string `yaml:"project" mapstructure:"project"`
+	// Mode defaults to "releases"
+	Mode string `yaml:"mode" mapstructure:"mode"`
+	// Token is a personal, project or group access token, needed for private projects
+	Token string `yaml:"token" mapstructure:"token"`
+}
+
+// Validate validates the GitLab source configuration
+func (c *Config) Validate() error {
+	if c.Project == "" {
+		return fmt.Errorf("project is required")
+	}
+	if strings.HasPrefix(c.Project, "/") || strings.HasSuffix(c.Project, "/") || !strings.Contains(c.Project, "/") {
+		return fmt.Errorf("project has to be the full path of the project, e.g. group/project: %s", c.Project)
+	}
+	if c.BaseURL != "" {
+		u, err := url.Parse(c.BaseURL)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("invalid base_url: %s", c.BaseURL)
+		}
+	}
+	if c.Mode != "" && !slices.Contains(validModes, c.Mode) {
+		return fmt.Errorf("invalid mode: %s", c.Mode)
+	}
+	return nil
+}
+
+func (c *Config) IsCrawler() {}
+
+func (c *Config) Build(name string) (model.Source, error) {
+	return NewAdapter(c, name)
+}
+
+// mode returns the configured mode, defaulting to releases
+func (c *Config) mode() string {
+	if c.Mode == "" {
+		return ModeReleases
+	}
+	return c.Mode
+}
+
+// baseURL returns the configured base URL without trailing slash, defaulting to gitlab.com
+func (c *Config) baseURL() string {
+	if c.BaseURL == "" {
+		return defaultBaseURL
+	}
+	return strings.TrimSuffix(c.BaseURL, "/")
+}
@@ -0,0 +1,57 @@
+package gitlab
+
+import (
+	"context"
+	"time"
+
+	"github.com/ryansiau/KeepUpdated/go/model"
+	"github.com/ryansiau/KeepUpdated/go/pkg/forge"
+)
+
+type Release struct {
+	TagName         string    `json:"tag_name"`
+	Name            string    `json:"name"`
+	Description     string    `json:"description"`
+	CreatedAt       time.Time `json:"created_at"`
+	ReleasedAt      time.Time `json:"released_at"`
+	UpcomingRelease bool      `json:"upcoming_release"`
+	Author          struct {
+		Username string `json:"username"`
+	} `json:"author"`
+	Links struct {
+		Self string `json:"self"`
+	} `json:"_links"`
+}
+
+func (a *Adapter) FetchReleases(ctx context.Context) ([]Release, error) {
+	var releases []Release
+	err := a.get(ctx, a.projectPath()+"/releases?per_page=30&order_by=released_at&sort=desc", &releases)
+	return releases, err
+}
+
+func (a *Adapter) fetchReleases(ctx context.Context) ([]model.Content, error) {
+	releases, err := a.FetchReleases(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var contents []model.Content
+	for _, release := range releases {
+		// releases scheduled in the future are notified once they're released
+		if release.UpcomingRelease {
+			continue
+		}
+
+		contents = append(contents, forge.Release{
+			Repository:  a.config.Project,
+			TagName:     release.TagName,
+			Name:        release.Name,
+			Body:        release.Description,
+			URL:         release.Links.Self,
+			Author:      release.Author.Username,
+			PublishedAt: release.ReleasedAt,
+		}.Content(a.contentID(release.TagName), a.SourceID(), "GitLab"))
+	}
+
+	return contents, nil
+}
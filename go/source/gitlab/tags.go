@@ -0,0 +1,49 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/ryansiau/KeepUpdated/go/model"
+	"github.com/ryansiau/KeepUpdated/go/pkg/forge"
+)
+
+type Tag struct {
+	Name    string `json:"name"`
+	Message string `json:"message"`
+	Commit  struct {
+		ID            string    `json:"id"`
+		CommittedDate time.Time `json:"committed_date"`
+		AuthorName    string    `json:"author_name"`
+	} `json:"commit"`
+}
+
+func (a *Adapter) FetchTags(ctx context.Context) ([]Tag, error) {
+	var tags []Tag
+	err := a.get(ctx, a.projectPath()+"/repository/tags?per_page=30&order_by=updated&sort=desc", &tags)
+	return tags, err
+}
+
+func (a *Adapter) fetchTags(ctx context.Context) ([]model.Content, error) {
+	tags, err := a.FetchTags(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var contents []model.Content
+	for _, tag := range tags {
+		contents = append(contents, forge.Tag{
+			Repository:  a.config.Project,
+			Name:        tag.Name,
+			Message:     tag.Message,
+			URL:         fmt.Sprintf("%s/%s/-/tags/%s", a.config.baseURL(), a.config.Project, url.PathEscape(tag.Name)),
+			Author:      tag.Commit.AuthorName,
+			CommitSHA:   tag.Commit.ID,
+			PublishedAt: tag.Commit.CommittedDate,
+		}.Content(a.contentID(tag.Name), a.SourceID(), "GitLab"))
+	}
+
+	return contents, nil
+}
@@ -0,0 +1,166 @@
+// Package graphql follows GraphQL APIs, mapping their responses into contents with the same mapping as json_api.
+package graphql
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jmespath/go-jmespath"
+	"resty.dev/v3"
+
+	"github.com/ryansiau/KeepUpdated/go/common"
+	"github.com/ryansiau/KeepUpdated/go/model"
+)
+
+// Adapter implements the Source interface for GraphQL APIs
+type Adapter struct {
+	client *resty.Client
+	config *Config
+	name   string
+}
+
+// NewAdapter creates a new GraphQL adapter with configuration
+func NewAdapter(config *Config, name string) (*Adapter, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	if name == "" {
+		name = "GraphQL: " + config.Endpoint
+	}
+
+	client := resty.New().
+		SetTimeout(30*time.Second).
+		SetHeader("User-Agent", common.HTTPClientUserAgent).
+		SetHeaders(config.Headers)
+	if config.Authorization != "" {
+		client.SetHeader("Authorization", config.Authorization)
+	}
+
+	return &Adapter{
+		client: client,
+		config: config,
+		name:   name,
+	}, nil
+}
+
+// Name returns the name of the source
+func (a *Adapter) Name() string {
+	return a.name
+}
+
+// Type returns the platform type
+func (a *Adapter) Type() string {
+	return "graphql"
+}
+
+// SourceID returns the identifier of the source.
+// an endpoint serves many queries, so the query and its variables are part of it.
+func (a *Adapter) SourceID() string {
+	variables, _ := json.Marshal(a.config.Variables)
+	sum := sha256.Sum256([]byte(a.config.Query + string(variables)))
+	return fmt.Sprintf("GraphQL:%s:%s", a.config.Endpoint, hex.EncodeToString(sum[:8]))
+}
+
+// Fetch runs the query, following the cursor of page_info, and maps the items of every page
+func (a *Adapter) Fetch(ctx context.Context) ([]model.Content, error) {
+	variables := maps.Clone(a.config.Variables)
+	if variables == nil {
+		variables = map[string]interface{}{}
+	}
+
+	// an item moving to the next page while paginating is listed twice
+	var contents []model.Content
+	seen := map[string]struct{}{}
+	for page := 0; page < a.config.maxPages(); page++ {
+		data, err := a.query(ctx, variables)
+		if err != nil {
+			return nil, err
+		}
+
+		pageContents, err := a.config.Mapping.Contents(data, a.SourceID(), "GraphQL", seen)
+		if err != nil {
+			return nil, err
+		}
+		contents = append(contents, pageContents...)
+
+		if a.config.PageInfo == "" {
+			break
+		}
+
+		cursor, hasNext := a.nextCursor(data)
+		if !hasNext {
+			break
+		}
+		variables[a.config.cursorVariable()] = cursor
+	}
+
+	// newest first, items without a date keep the order of the response
+	slices.SortStableFunc(contents, func(a, b model.Content) int {
+		return b.PublishedAt.Compare(a.PublishedAt)
+	})
+
+	return contents, nil
+}
+
+// query sends the query with the variables and returns the whole decoded response, data included
+func (a *Adapter) query(ctx context.Context, variables map[string]interface{}) (interface{}, error) {
+	resp, err := a.client.R().
+		SetContext(ctx).
+		SetHeader("Content-Type", "application/json").
+		SetHeader("Accept", "application/json").
+		SetBody(map[string]interface{}{
+			"query":     a.config.Query,
+			"variables": variables,
+		}).
+		Post(a.config.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode() != 200 {
+		return nil, fmt.Errorf("status code: %d, body: %s", resp.StatusCode(), resp.String())
+	}
+
+	var data map[string]interface{}
+	if err := json.Unmarshal(resp.Bytes(), &data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	// GraphQL reports the errors of a query with a 200
+	if errs, ok := data["errors"].([]interface{}); ok && len(errs) > 0 {
+		var messages []string
+		for _, e := range errs {
+			if m, ok := e.(map[string]interface{}); ok {
+				messages = append(messages, fmt.Sprint(m["message"]))
+			}
+		}
+		return nil, fmt.Errorf("query failed: %s", strings.Join(messages, "; "))
+	}
+
+	return data, nil
+}
+
+// nextCursor reads hasNextPage and endCursor of the page info
+func (a *Adapter) nextCursor(data interface{}) (string, bool) {
+	value, err := jmespath.Search(a.config.PageInfo, data)
+	if err != nil {
+		return "", false
+	}
+
+	pageInfo, ok := value.(map[string]interface{})
+	if !ok {
+		return "", false
+	}
+
+	hasNext, _ := pageInfo["hasNextPage"].(bool)
+	cursor, _ := pageInfo["endCursor"].(string)
+	return cursor, hasNext && cursor != ""
+}
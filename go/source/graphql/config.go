@@ -0,0 +1,76 @@
+package graphql
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/jmespath/go-jmespath"
+
+	"github.com/ryansiau/KeepUpdated/go/model"
+	json_api "github.com/ryansiau/KeepUpdated/go/source/json-api"
+)
+
+// defaultMaxPages is used when max_pages is not configured
+const defaultMaxPages = 5
+
+// Config represents the configuration for a GraphQL source
+type Config struct {
+	Endpoint  string                 `yaml:"endpoint" mapstructure:"endpoint"`
+	Query     string                 `yaml:"query" mapstructure:"query"`
+	Variables map[string]interface{} `yaml:"variables" mapstructure:"variables"`
+	// Authorization is sent as the Authorization header, e.g. "Bearer <token>"
+	Authorization string            `yaml:"authorization" mapstructure:"authorization"`
+	Headers       map[string]string `yaml:"headers" mapstructure:"headers"`
+
+	// PageInfo is the expression of the connection's pageInfo, e.g. data.repository.issues.pageInfo.
+	// while its hasNextPage is true, the query is sent again with its endCursor in the cursor variable.
+	PageInfo       string `yaml:"page_info" mapstructure:"page_info"`
+	CursorVariable string `yaml:"cursor_variable" mapstructure:"cursor_variable"`
+	// MaxPages caps the requests of an execution, 5 by default
+	MaxPages int `yaml:"max_pages" mapstructure:"max_pages"`
+
+	json_api.Mapping `yaml:",inline" mapstructure:",squash"`
+}
+
+// Validate validates the GraphQL source configuration
+func (c *Config) Validate() error {
+	u, err := url.Parse(c.Endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("endpoint has to be an absolute http(s) URL: %s", c.Endpoint)
+	}
+	if c.Query == "" {
+		return fmt.Errorf("query is required")
+	}
+	if c.Items == "" {
+		return fmt.Errorf("items is required, GraphQL responses are never a bare array")
+	}
+	if c.PageInfo != "" {
+		if _, err := jmespath.Compile(c.PageInfo); err != nil {
+			return fmt.Errorf("invalid page_info expression %q: %w", c.PageInfo, err)
+		}
+	}
+	if c.MaxPages < 0 {
+		return fmt.Errorf("max_pages can't be negative")
+	}
+	return c.Mapping.Validate()
+}
+
+func (c *Config) IsCrawler() {}
+
+func (c *Config) Build(name string) (model.Source, error) {
+	return NewAdapter(c, name)
+}
+
+func (c *Config) cursorVariable() string {
+	if c.CursorVariable == "" {
+		return "cursor"
+	}
+	return c.CursorVariable
+}
+
+func (c *Config) maxPages() int {
+	if c.MaxPages == 0 {
+		return defaultMaxPages
+	}
+	return c.MaxPages
+}
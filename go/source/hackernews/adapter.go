@@ -0,0 +1,149 @@
+package hackernews
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"resty.dev/v3"
+
+	"github.com/ryansiau/KeepUpdated/go/common"
+	"github.com/ryansiau/KeepUpdated/go/model"
+)
+
+// Story is a Hacker News story, as returned by either API
+type Story struct {
+	ID          int
+	Title       string
+	URL         string
+	Text        string
+	Author      string
+	Type        string
+	Points      int
+	Comments    int
+	PublishedAt time.Time
+}
+
+// DiscussionURL returns the URL of the story's comments on Hacker News
+func (s Story) DiscussionURL() string {
+	return "https://news.ycombinator.com/item?id=" + strconv.Itoa(s.ID)
+}
+
+// Adapter implements the Source interface for Hacker News
+type Adapter struct {
+	client *resty.Client
+	config *Config
+	name   string
+}
+
+// NewAdapter creates a new Hacker News adapter with configuration
+func NewAdapter(config *Config, name string) (*Adapter, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	if name == "" {
+		name = fmt.Sprintf("Hacker News: %s", config.Feed)
+		if config.Query != "" {
+			name = fmt.Sprintf("Hacker News: %s", config.Query)
+		}
+	}
+
+	client := resty.New().
+		SetTimeout(30*time.Second).
+		SetHeader("User-Agent", common.HTTPClientUserAgent)
+
+	return &Adapter{
+		client: client,
+		config: config,
+		name:   name,
+	}, nil
+}
+
+// Name returns the name of the source
+func (a *Adapter) Name() string {
+	return a.name
+}
+
+// Type returns the platform type
+func (a *Adapter) Type() string {
+	return "hackernews"
+}
+
+// SourceID returns the identifier of the source
+func (a *Adapter) SourceID() string {
+	return "HackerNews:" + a.feedKey()
+}
+
+// feedKey identifies the feed or the search of the source
+func (a *Adapter) feedKey() string {
+	if a.config.Query != "" {
+		return "search:" + a.config.Query
+	}
+	return a.config.Feed
+}
+
+func (a *Adapter) maxItems() int {
+	if a.config.MaxItems == 0 {
+		return defaultMaxItems
+	}
+	return a.config.MaxItems
+}
+
+// FetchStories lists the stories of the feed or the search
+func (a *Adapter) FetchStories(ctx context.Context) ([]Story, error) {
+	if a.config.Query != "" {
+		return a.fetchAlgolia(ctx)
+	}
+	return a.fetchFirebase(ctx)
+}
+
+// Fetch retrieves the stories that crossed min_points and min_comments.
+// stories below them are left out, so they are new to the worker once they cross.
+func (a *Adapter) Fetch(ctx context.Context) ([]model.Content, error) {
+	stories, err := a.FetchStories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// newest first
+	slices.SortStableFunc(stories, func(a, b Story) int {
+		return b.PublishedAt.Compare(a.PublishedAt)
+	})
+
+	var contents []model.Content
+	for _, story := range stories {
+		if story.Points < a.config.MinPoints || story.Comments < a.config.MinComments {
+			continue
+		}
+
+		// Ask HN and the like have no link of their own
+		url := story.URL
+		if url == "" {
+			url = story.DiscussionURL()
+		}
+
+		contents = append(contents, model.Content{
+			ID:          fmt.Sprintf("hackernews:%s:%d", a.feedKey(), story.ID),
+			SourceID:    a.SourceID(),
+			Title:       story.Title,
+			Description: story.Text,
+			URL:         url,
+			Author:      story.Author,
+			Platform:    "Hacker News",
+			PublishedAt: story.PublishedAt,
+			UpdatedAt:   time.Now(),
+			Metadata: map[string]interface{}{
+				"hn_id":          story.ID,
+				"type":           story.Type,
+				"points":         story.Points,
+				"num_comments":   story.Comments,
+				"discussion_url": story.DiscussionURL(),
+			},
+		})
+	}
+
+	return contents, nil
+}
@@ -0,0 +1,80 @@
+package hackernews
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// algoliaURL is the base of the Algolia search API of Hacker News
+const algoliaURL = "https://hn.algolia.com/api/v1"
+
+type algoliaHit struct {
+	ObjectID    string `json:"objectID"`
+	Title       string `json:"title"`
+	URL         string `json:"url"`
+	StoryText   string `json:"story_text"`
+	Author      string `json:"author"`
+	Points      int    `json:"points"`
+	NumComments int    `json:"num_comments"`
+	CreatedAtI  int64  `json:"created_at_i"`
+}
+
+// fetchAlgolia searches the latest stories matching the query
+func (a *Adapter) fetchAlgolia(ctx context.Context) ([]Story, error) {
+	var res struct {
+		Hits []algoliaHit `json:"hits"`
+	}
+
+	req := a.client.R().
+		SetContext(ctx).
+		SetResult(&res).
+		SetQueryParam("query", a.config.Query).
+		SetQueryParam("tags", "story").
+		SetQueryParam("hitsPerPage", strconv.Itoa(a.maxItems()))
+
+	// the thresholds are applied by Fetch too, asking Algolia for them only spares the hits that would be dropped
+	var numericFilters []string
+	if a.config.MinPoints > 0 {
+		numericFilters = append(numericFilters, fmt.Sprintf("points>=%d", a.config.MinPoints))
+	}
+	if a.config.MinComments > 0 {
+		numericFilters = append(numericFilters, fmt.Sprintf("num_comments>=%d", a.config.MinComments))
+	}
+	if len(numericFilters) > 0 {
+		req.SetQueryParam("numericFilters", strings.Join(numericFilters, ","))
+	}
+
+	resp, err := req.Get(algoliaURL + "/search_by_date")
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode() != 200 {
+		return nil, fmt.Errorf("status code: %d, body: %s", resp.StatusCode(), resp.String())
+	}
+
+	var stories []Story
+	for _, hit := range res.Hits {
+		id, err := strconv.Atoi(hit.ObjectID)
+		if err != nil {
+			continue
+		}
+
+		stories = append(stories, Story{
+			ID:          id,
+			Title:       hit.Title,
+			URL:         hit.URL,
+			Text:        hit.StoryText,
+			Author:      hit.Author,
+			Type:        "story",
+			Points:      hit.Points,
+			Comments:    hit.NumComments,
+			PublishedAt: time.Unix(hit.CreatedAtI, 0),
+		})
+	}
+
+	return stories, nil
+}
@@ -0,0 +1,59 @@
+package hackernews
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/ryansiau/KeepUpdated/go/model"
+)
+
+// Story lists of the official API
+const (
+	FeedTop  = "top"
+	FeedNew  = "new"
+	FeedBest = "best"
+	FeedShow = "show"
+	FeedAsk  = "ask"
+)
+
+var validFeeds = []string{FeedTop, FeedNew, FeedBest, FeedShow, FeedAsk}
+
+// defaultMaxItems is used when max_items is not configured
+const defaultMaxItems = 30
+
+// Config represents the configuration for a Hacker News source
+type Config struct {
+	// Feed is one of top, new, best, show or ask, read from the official Firebase API
+	Feed string `yaml:"feed" mapstructure:"feed"`
+	// Query searches the stories through the Algolia API instead, e.g. "golang"
+	Query string `yaml:"query" mapstructure:"query"`
+	// MinPoints and MinComments hold a story back until it crosses them,
+	// it is then notified once on the first execution that sees it above both
+	MinPoints   int `yaml:"min_points" mapstructure:"min_points"`
+	MinComments int `yaml:"min_comments" mapstructure:"min_comments"`
+	// MaxItems is the number of stories checked on every execution, 30 by default
+	MaxItems int `yaml:"max_items" mapstructure:"max_items"`
+}
+
+// Validate validates the Hacker News source configuration
+func (c *Config) Validate() error {
+	if (c.Feed == "") == (c.Query == "") {
+		return fmt.Errorf("exactly one of feed or query is required")
+	}
+	if c.Feed != "" && !slices.Contains(validFeeds, c.Feed) {
+		return fmt.Errorf("invalid feed: %s", c.Feed)
+	}
+	if c.MinPoints < 0 || c.MinComments < 0 {
+		return fmt.Errorf("min_points and min_comments can't be negative")
+	}
+	if c.MaxItems < 0 || c.MaxItems > 500 {
+		return fmt.Errorf("max_items has to be between 1 and 500")
+	}
+	return nil
+}
+
+func (c *Config) IsCrawler() {}
+
+func (c *Config) Build(name string) (model.Source, error) {
+	return NewAdapter(c, name)
+}
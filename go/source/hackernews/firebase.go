@@ -0,0 +1,109 @@
+package hackernews
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// firebaseURL is the base of the official Hacker News API
+const firebaseURL = "https://hacker-news.firebaseio.com/v0"
+
+// itemConcurrency limits the items requested at once, the API has one endpoint per item
+const itemConcurrency = 8
+
+type firebaseItem struct {
+	ID          int    `json:"id"`
+	Type        string `json:"type"`
+	By          string `json:"by"`
+	Time        int64  `json:"time"`
+	Title       string `json:"title"`
+	URL         string `json:"url"`
+	Text        string `json:"text"`
+	Score       int    `json:"score"`
+	Descendants int    `json:"descendants"`
+	Dead        bool   `json:"dead"`
+	Deleted     bool   `json:"deleted"`
+}
+
+// fetchFirebase reads the story list from the official API, then every story of it
+func (a *Adapter) fetchFirebase(ctx context.Context) ([]Story, error) {
+	var ids []int
+	resp, err := a.client.R().
+		SetContext(ctx).
+		SetResult(&ids).
+		SetForceResponseContentType("application/json").
+		Get(fmt.Sprintf("%s/%sstories.json", firebaseURL, a.config.Feed))
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode() != 200 {
+		return nil, fmt.Errorf("status code: %d, body: %s", resp.StatusCode(), resp.String())
+	}
+
+	if len(ids) > a.maxItems() {
+		ids = ids[:a.maxItems()]
+	}
+
+	items := make([]*firebaseItem, len(ids))
+	errs := make([]error, len(ids))
+
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, itemConcurrency)
+	for i, id := range ids {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			items[i], errs[i] = a.fetchItem(ctx, id)
+		}()
+	}
+	wg.Wait()
+
+	var stories []Story
+	for i, item := range items {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		if item == nil || item.Dead || item.Deleted {
+			continue
+		}
+
+		stories = append(stories, Story{
+			ID:          item.ID,
+			Title:       item.Title,
+			URL:         item.URL,
+			Text:        item.Text,
+			Author:      item.By,
+			Type:        item.Type,
+			Points:      item.Score,
+			Comments:    item.Descendants,
+			PublishedAt: time.Unix(item.Time, 0),
+		})
+	}
+
+	return stories, nil
+}
+
+// fetchItem requests a single item, nil when it doesn't exist
+func (a *Adapter) fetchItem(ctx context.Context, id int) (*firebaseItem, error) {
+	var item *firebaseItem
+	resp, err := a.client.R().
+		SetContext(ctx).
+		SetResult(&item).
+		SetForceResponseContentType("application/json").
+		Get(fmt.Sprintf("%s/item/%d.json", firebaseURL, id))
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode() != 200 {
+		return nil, fmt.Errorf("status code: %d, body: %s", resp.StatusCode(), resp.String())
+	}
+
+	return item, nil
+}
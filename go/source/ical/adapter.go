@@ -0,0 +1,164 @@
+// Package ical notifies the upcoming events of an iCalendar feed, e.g. CFP deadlines and maintenance windows.
+package ical
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	ics "github.com/arran4/golang-ical"
+	"resty.dev/v3"
+
+	"github.com/ryansiau/KeepUpdated/go/common"
+	"github.com/ryansiau/KeepUpdated/go/model"
+)
+
+// Adapter implements the Source interface for iCalendar feeds
+type Adapter struct {
+	client *resty.Client
+	config *Config
+	name   string
+}
+
+// NewAdapter creates a new iCalendar adapter with configuration
+func NewAdapter(config *Config, name string) (*Adapter, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	if name == "" {
+		name = fmt.Sprintf("iCal: %s", config.URL)
+	}
+
+	client := resty.New().
+		SetTimeout(30*time.Second).
+		SetHeader("User-Agent", common.HTTPClientUserAgent)
+
+	return &Adapter{
+		client: client,
+		config: config,
+		name:   name,
+	}, nil
+}
+
+// Name returns the name of the source
+func (a *Adapter) Name() string {
+	return a.name
+}
+
+// Type returns the platform type
+func (a *Adapter) Type() string {
+	return "ical"
+}
+
+// SourceID returns the identifier of the source
+func (a *Adapter) SourceID() string {
+	return "iCal:" + a.feedURL()
+}
+
+// feedURL returns the URL of the feed, webcal being http(s) under another name
+func (a *Adapter) feedURL() string {
+	if rest, ok := strings.CutPrefix(a.config.URL, "webcal://"); ok {
+		return "https://" + rest
+	}
+	return a.config.URL
+}
+
+// Fetch downloads the feed and returns the occurrences of the events within the look-ahead window
+func (a *Adapter) Fetch(ctx context.Context) ([]model.Content, error) {
+	resp, err := a.client.R().
+		SetContext(ctx).
+		SetHeader("Accept", "text/calendar").
+		Get(a.feedURL())
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.IsError() {
+		return nil, fmt.Errorf("status code: %d, body: %s", resp.StatusCode(), resp.String())
+	}
+
+	calendar, err := ics.ParseCalendar(bytes.NewReader(resp.Bytes()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse calendar: %w", err)
+	}
+
+	now := time.Now()
+	occurrences := expand(calendar.Events(), now, now.AddDate(0, 0, a.config.lookAheadDays()))
+
+	contents := make([]model.Content, 0, len(occurrences))
+	for _, o := range occurrences {
+		contents = append(contents, a.content(o))
+	}
+
+	// the furthest occurrence is the latest to enter the window
+	slices.SortFunc(contents, func(x, y model.Content) int {
+		return y.PublishedAt.Compare(x.PublishedAt)
+	})
+
+	return contents, nil
+}
+
+func (a *Adapter) content(o occurrence) model.Content {
+	event := o.event
+
+	// occurrences of recurring events are told apart by their recurrence id
+	id := a.SourceID() + ":" + o.uid
+	if o.recurrenceID != "" {
+		id += ":" + o.recurrenceID
+	}
+
+	timeFormat := time.RFC3339
+	if o.allDay {
+		timeFormat = time.DateOnly
+	}
+
+	metadata := map[string]interface{}{
+		"uid":     o.uid,
+		"start":   o.start.Format(timeFormat),
+		"end":     o.end.Format(timeFormat),
+		"all_day": o.allDay,
+	}
+	if o.recurrenceID != "" {
+		metadata["recurrence_id"] = o.recurrenceID
+	}
+
+	location := propertyValue(event, ics.ComponentPropertyLocation)
+	if location != "" {
+		metadata["location"] = location
+	}
+
+	var author string
+	if organizer := event.GetProperty(ics.ComponentPropertyOrganizer); organizer != nil {
+		email := strings.TrimPrefix(strings.TrimPrefix(organizer.Value, "mailto:"), "MAILTO:")
+		author = email
+		if cn, ok := organizer.ICalParameters[string(ics.ParameterCn)]; ok && len(cn) > 0 && cn[0] != "" {
+			author = cn[0]
+		}
+		metadata["organizer"] = author
+		metadata["organizer_email"] = email
+	}
+
+	return model.Content{
+		ID:          id,
+		SourceID:    a.SourceID(),
+		Title:       propertyValue(event, ics.ComponentPropertySummary),
+		Description: propertyValue(event, ics.ComponentPropertyDescription),
+		URL:         propertyValue(event, ics.ComponentPropertyUrl),
+		Author:      author,
+		Platform:    "iCal",
+		PublishedAt: o.start,
+		UpdatedAt:   time.Now(),
+		Metadata:    metadata,
+	}
+}
+
+func propertyValue(event *ics.VEvent, property ics.ComponentProperty) string {
+	if p := event.GetProperty(property); p != nil {
+		return strings.TrimSpace(p.Value)
+	}
+	return ""
+}
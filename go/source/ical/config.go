@@ -0,0 +1,51 @@
+package ical
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/ryansiau/KeepUpdated/go/model"
+)
+
+// defaultLookAheadDays is used when look_ahead_days is not configured
+const defaultLookAheadDays = 30
+
+// Config represents the configuration for an iCalendar feed source
+type Config struct {
+	// URL of the .ics feed, webcal:// URLs are fetched over https
+	URL string `yaml:"url" mapstructure:"url"`
+	// LookAheadDays is how far ahead the events are notified, 30 days by default.
+	// an event is notified once, on the first execution it falls within the window.
+	LookAheadDays int `yaml:"look_ahead_days" mapstructure:"look_ahead_days"`
+}
+
+// Validate validates the iCalendar source configuration
+func (c *Config) Validate() error {
+	if c.URL == "" {
+		return fmt.Errorf("url is required")
+	}
+	u, err := url.Parse(c.URL)
+	if err != nil {
+		return fmt.Errorf("invalid url: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" && u.Scheme != "webcal" {
+		return fmt.Errorf("url has to be http, https or webcal")
+	}
+	if c.LookAheadDays < 0 {
+		return fmt.Errorf("look_ahead_days can't be negative")
+	}
+	return nil
+}
+
+func (c *Config) IsCrawler() {}
+
+func (c *Config) Build(name string) (model.Source, error) {
+	return NewAdapter(c, name)
+}
+
+func (c *Config) lookAheadDays() int {
+	if c.LookAheadDays == 0 {
+		return defaultLookAheadDays
+	}
+	return c.LookAheadDays
+}
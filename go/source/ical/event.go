@@ -0,0 +1,279 @@
+package ical
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	ics "github.com/arran4/golang-ical"
+	"github.com/teambition/rrule-go"
+)
+
+// occurrence is an instance of an event, recurring events have one per recurrence
+type occurrence struct {
+	event *ics.VEvent
+	uid   string
+	// recurrenceID is the original start of the instance of a recurring event, empty for single events
+	recurrenceID string
+	start, end   time.Time
+	allDay       bool
+}
+
+// expand returns the occurrences of the events overlapping [from, to], unique by uid and recurrence id.
+// instances overriding an occurrence of a recurring event, identified by their RECURRENCE-ID, replace it.
+// events repeating a uid, as published by some calendars, keep the one with the highest SEQUENCE or the last one.
+func expand(events []*ics.VEvent, from, to time.Time) []occurrence {
+	// overrides by uid and recurrence id
+	overrides := map[string]map[string]*ics.VEvent{}
+	for _, event := range events {
+		recurrenceID := event.GetProperty(ics.ComponentPropertyRecurrenceId)
+		if recurrenceID == nil {
+			continue
+		}
+		times, allDay, err := parseTimes(recurrenceID)
+		if err != nil || len(times) == 0 {
+			continue
+		}
+		uid := propertyValue(event, ics.ComponentPropertyUniqueId)
+		if overrides[uid] == nil {
+			overrides[uid] = map[string]*ics.VEvent{}
+		}
+		overrides[uid][formatRecurrenceID(times[0], allDay)] = event
+	}
+
+	var occurrences []occurrence
+	index := map[string]int{}
+	add := func(o occurrence) {
+		if isCancelled(o.event) || !o.end.After(from) || o.start.After(to) {
+			return
+		}
+		key := o.uid + "\x00" + o.recurrenceID
+		if idx, ok := index[key]; ok {
+			if sequence(o.event) >= sequence(occurrences[idx].event) {
+				occurrences[idx] = o
+			}
+			return
+		}
+		index[key] = len(occurrences)
+		occurrences = append(occurrences, o)
+	}
+
+	for _, event := range events {
+		if event.GetProperty(ics.ComponentPropertyRecurrenceId) != nil {
+			continue
+		}
+
+		uid := propertyValue(event, ics.ComponentPropertyUniqueId)
+		start, duration, allDay, err := eventTimes(event)
+		if err != nil {
+			continue
+		}
+
+		if !isRecurring(event) {
+			add(occurrence{event: event, uid: uid, start: start, end: start.Add(duration), allDay: allDay})
+			continue
+		}
+
+		set, err := recurrenceSet(event, start)
+		if err != nil {
+			continue
+		}
+
+		// occurrences starting before the window may still be going on
+		for _, recurrenceStart := range set.Between(from.Add(-duration), to, true) {
+			recurrenceID := formatRecurrenceID(recurrenceStart, allDay)
+			o := occurrence{event: event, uid: uid, recurrenceID: recurrenceID, start: recurrenceStart, end: recurrenceStart.Add(duration), allDay: allDay}
+
+			if override, ok := overrides[uid][recurrenceID]; ok {
+				delete(overrides[uid], recurrenceID)
+				o.event = override
+				if overrideStart, overrideDuration, overrideAllDay, err := eventTimes(override); err == nil {
+					o.start, o.end, o.allDay = overrideStart, overrideStart.Add(overrideDuration), overrideAllDay
+				}
+			}
+
+			add(o)
+		}
+	}
+
+	// overrides moved into the window from an occurrence outside of it
+	for uid, byRecurrenceID := range overrides {
+		for recurrenceID, override := range byRecurrenceID {
+			start, duration, allDay, err := eventTimes(override)
+			if err != nil {
+				continue
+			}
+			add(occurrence{event: override, uid: uid, recurrenceID: recurrenceID, start: start, end: start.Add(duration), allDay: allDay})
+		}
+	}
+
+	return occurrences
+}
+
+func isRecurring(event *ics.VEvent) bool {
+	return event.GetProperty(ics.ComponentPropertyRrule) != nil || event.GetProperty(ics.ComponentPropertyRdate) != nil
+}
+
+// sequence is the revision of the event, 0 when it's missing
+func sequence(event *ics.VEvent) int {
+	value, _ := strconv.Atoi(propertyValue(event, ics.ComponentPropertySequence))
+	return value
+}
+
+func isCancelled(event *ics.VEvent) bool {
+	return strings.EqualFold(propertyValue(event, ics.ComponentPropertyStatus), string(ics.ObjectStatusCancelled))
+}
+
+// eventTimes returns the start and the duration of the event, from DTEND or DURATION.
+// an all-day event without either lasts a day, as per RFC 5545.
+func eventTimes(event *ics.VEvent) (time.Time, time.Duration, bool, error) {
+	dtStart := event.GetProperty(ics.ComponentPropertyDtStart)
+	if dtStart == nil {
+		return time.Time{}, 0, false, fmt.Errorf("missing DTSTART")
+	}
+	starts, allDay, err := parseTimes(dtStart)
+	if err != nil {
+		return time.Time{}, 0, false, err
+	}
+	start := starts[0]
+
+	var duration time.Duration
+	if dtEnd := event.GetProperty(ics.ComponentPropertyDtEnd); dtEnd != nil {
+		ends, _, err := parseTimes(dtEnd)
+		if err != nil {
+			return time.Time{}, 0, false, err
+		}
+		duration = ends[0].Sub(start)
+	} else if d := event.GetProperty(ics.ComponentPropertyDuration); d != nil {
+		duration, err = parseDuration(d.Value)
+		if err != nil {
+			return time.Time{}, 0, false, err
+		}
+	} else if allDay {
+		duration = 24 * time.Hour
+	}
+
+	return start, max(duration, 0), allDay, nil
+}
+
+// recurrenceSet builds the recurrences of the event from its RRULE, RDATE and EXDATE
+func recurrenceSet(event *ics.VEvent, start time.Time) (*rrule.Set, error) {
+	set := &rrule.Set{}
+	set.DTStart(start)
+
+	if rule := event.GetProperty(ics.ComponentPropertyRrule); rule != nil {
+		option, err := rrule.StrToROptionInLocation(rule.Value, start.Location())
+		if err != nil {
+			return nil, err
+		}
+		option.Dtstart = start
+
+		r, err := rrule.NewRRule(*option)
+		if err != nil {
+			return nil, err
+		}
+		set.RRule(r)
+	} else {
+		// the start is always the first occurrence, RDATE adds the others
+		set.RDate(start)
+	}
+
+	for _, rdate := range event.GetProperties(ics.ComponentPropertyRdate) {
+		times, _, err := parseTimes(rdate)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range times {
+			set.RDate(t)
+		}
+	}
+
+	for _, exdate := range event.GetProperties(ics.ComponentPropertyExdate) {
+		times, _, err := parseTimes(exdate)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range times {
+			set.ExDate(t)
+		}
+	}
+
+	return set, nil
+}
+
+// formatRecurrenceID formats the original start of an occurrence, the same way whichever time zone it's written in
+func formatRecurrenceID(t time.Time, allDay bool) string {
+	if allDay {
+		return t.Format("20060102")
+	}
+	return t.UTC().Format("20060102T150405Z")
+}
+
+// parseTimes parses the comma separated DATE or DATE-TIME values of the property, in its TZID when it has one.
+// floating times are local to the worker. it reports whether the values are dates, i.e. all-day.
+func parseTimes(property *ics.IANAProperty) ([]time.Time, bool, error) {
+	loc := time.Local
+	if tzid, ok := property.ICalParameters[string(ics.ParameterTzid)]; ok && len(tzid) > 0 {
+		// ids of custom time zones that aren't in the IANA database fall back to UTC
+		loc = time.UTC
+		if l, err := time.LoadLocation(strings.Trim(tzid[0], `"`)); err == nil {
+			loc = l
+		}
+	}
+
+	var times []time.Time
+	var allDay bool
+	for _, value := range strings.Split(property.Value, ",") {
+		// periods of RDATE only need their start
+		value, _, _ = strings.Cut(strings.TrimSpace(value), "/")
+
+		var t time.Time
+		var err error
+		switch {
+		case strings.HasSuffix(value, "Z"):
+			t, err = time.Parse("20060102T150405Z", value)
+		case strings.Contains(value, "T"):
+			t, err = time.ParseInLocation("20060102T150405", value, loc)
+		default:
+			allDay = true
+			t, err = time.ParseInLocation("20060102", value, loc)
+		}
+		if err != nil {
+			return nil, false, fmt.Errorf("invalid %s: %w", property.IANAToken, err)
+		}
+		times = append(times, t)
+	}
+
+	return times, allDay, nil
+}
+
+var durationRegex = regexp.MustCompile(`^([+-])?P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// parseDuration parses the DURATION values of RFC 5545, e.g. PT1H30M or P1W
+func parseDuration(s string) (time.Duration, error) {
+	match := durationRegex.FindStringSubmatch(strings.TrimSpace(s))
+	if match == nil {
+		return 0, fmt.Errorf("invalid duration: %s", s)
+	}
+
+	units := []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+
+	var duration time.Duration
+	for idx, unit := range units {
+		if match[idx+2] == "" {
+			continue
+		}
+		n, err := strconv.Atoi(match[idx+2])
+		if err != nil {
+			return 0, err
+		}
+		duration += time.Duration(n) * unit
+	}
+
+	if match[1] == "-" {
+		duration = -duration
+	}
+	return duration, nil
+}
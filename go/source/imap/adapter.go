@@ -0,0 +1,226 @@
+// Package imap reads the messages of a mailbox, for vendors that only announce their changes by email.
+package imap
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/textproto"
+	"slices"
+	"strconv"
+	"time"
+
+	goimap "github.com/emersion/go-imap"
+	"github.com/emersion/go-imap/client"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ryansiau/KeepUpdated/go/model"
+)
+
+// tlsConfig is a variable so a local stand-in server with its own certificate can take the server's place
+var tlsConfig = &tls.Config{}
+
+// Adapter implements the Source interface for an IMAP mailbox
+type Adapter struct {
+	config *Config
+	name   string
+
+	// uids maps the ids of the fetched contents to their messages, to acknowledge them.
+	// a message delivered twice, e.g. to several addresses, is one content with several messages.
+	uids        map[string][]uint32
+	uidValidity uint32
+}
+
+var _ model.AcknowledgingSource = (*Adapter)(nil)
+
+// NewAdapter creates a new IMAP adapter with configuration
+func NewAdapter(config *Config, name string) (*Adapter, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	if name == "" {
+		name = fmt.Sprintf("IMAP: %s/%s", config.Username, config.folder())
+	}
+
+	return &Adapter{
+		config: config,
+		name:   name,
+	}, nil
+}
+
+// Name returns the name of the source
+func (a *Adapter) Name() string {
+	return a.name
+}
+
+// Type returns the platform type
+func (a *Adapter) Type() string {
+	return "imap"
+}
+
+// SourceID returns the identifier of the source, the criteria are part of it as they pick different messages
+func (a *Adapter) SourceID() string {
+	id := fmt.Sprintf("IMAP:%s@%s/%s", a.config.Username, a.config.Host, a.config.folder())
+	if a.config.From != "" {
+		id += ":from=" + a.config.From
+	}
+	if a.config.Subject != "" {
+		id += ":subject=" + a.config.Subject
+	}
+	return id
+}
+
+// connect logs into the server over TLS, or over a plain connection upgraded with STARTTLS
+func (a *Adapter) connect() (*client.Client, error) {
+	addr := net.JoinHostPort(a.config.Host, strconv.Itoa(a.config.port()))
+	dialer := &net.Dialer{Timeout: 30 * time.Second}
+
+	tlsConf := tlsConfig.Clone()
+	tlsConf.ServerName = a.config.Host
+
+	var c *client.Client
+	var err error
+	if a.config.StartTLS {
+		c, err = client.DialWithDialer(dialer, addr)
+		if err == nil {
+			err = c.StartTLS(tlsConf)
+		}
+	} else {
+		c, err = client.DialWithDialerTLS(dialer, addr, tlsConf)
+	}
+	if err != nil {
+		if c != nil {
+			_ = c.Logout()
+		}
+		return nil, fmt.Errorf("failed to connect to %s: %w", addr, err)
+	}
+	c.Timeout = 30 * time.Second
+
+	if err := c.Login(a.config.Username, a.config.Password); err != nil {
+		_ = c.Logout()
+		return nil, fmt.Errorf("failed to log in as %s: %w", a.config.Username, err)
+	}
+
+	return c, nil
+}
+
+// Fetch reads the latest messages of the folder matching the criteria, without flagging them as seen
+func (a *Adapter) Fetch(ctx context.Context) ([]model.Content, error) {
+	c, err := a.connect()
+	if err != nil {
+		return nil, err
+	}
+	defer c.Logout()
+
+	mailbox, err := c.Select(a.config.folder(), true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select %s: %w", a.config.folder(), err)
+	}
+	a.uidValidity = mailbox.UidValidity
+
+	criteria := &goimap.SearchCriteria{Header: textproto.MIMEHeader{}}
+	if a.config.From != "" {
+		criteria.Header.Add("From", a.config.From)
+	}
+	if a.config.Subject != "" {
+		criteria.Header.Add("Subject", a.config.Subject)
+	}
+	// messages marked as read have been processed already
+	if a.config.After == AfterMarkRead {
+		criteria.WithoutFlags = []string{goimap.SeenFlag}
+	}
+
+	uids, err := c.UidSearch(criteria)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search messages: %w", err)
+	}
+	if len(uids) == 0 {
+		return nil, nil
+	}
+
+	// uids grow with every new message, keep the latest ones
+	slices.Sort(uids)
+	if len(uids) > a.config.maxMessages() {
+		uids = uids[len(uids)-a.config.maxMessages():]
+	}
+
+	seqSet := new(goimap.SeqSet)
+	seqSet.AddNum(uids...)
+
+	section := &goimap.BodySectionName{Peek: true}
+	items := []goimap.FetchItem{goimap.FetchUid, goimap.FetchInternalDate, section.FetchItem()}
+
+	messages := make(chan *goimap.Message, 10)
+	done := make(chan error, 1)
+	go func() {
+		done <- c.UidFetch(seqSet, items, messages)
+	}()
+
+	a.uids = map[string][]uint32{}
+	var contents []model.Content
+	for msg := range messages {
+		content, err := a.parseMessage(msg, msg.GetBody(section))
+		if err != nil {
+			logrus.WithField("source", a.name).Warnf("Skipping message %d: %v", msg.Uid, err)
+			continue
+		}
+		if _, ok := a.uids[content.ID]; !ok {
+			contents = append(contents, content)
+		}
+		a.uids[content.ID] = append(a.uids[content.ID], msg.Uid)
+	}
+	if err := <-done; err != nil {
+		return nil, fmt.Errorf("failed to fetch messages: %w", err)
+	}
+
+	slices.SortFunc(contents, func(x, y model.Content) int {
+		return y.PublishedAt.Compare(x.PublishedAt)
+	})
+
+	return contents, nil
+}
+
+// Acknowledge marks the messages of the processed contents as read, or moves them, as configured
+func (a *Adapter) Acknowledge(ctx context.Context, contents []model.Content) error {
+	if a.config.After == "" {
+		return nil
+	}
+
+	seqSet := new(goimap.SeqSet)
+	for _, content := range contents {
+		seqSet.AddNum(a.uids[content.ID]...)
+	}
+	if seqSet.Empty() {
+		return nil
+	}
+
+	c, err := a.connect()
+	if err != nil {
+		return err
+	}
+	defer c.Logout()
+
+	mailbox, err := c.Select(a.config.folder(), false)
+	if err != nil {
+		return fmt.Errorf("failed to select %s: %w", a.config.folder(), err)
+	}
+	// the uids no longer point to the same messages once the folder is recreated
+	if mailbox.UidValidity != a.uidValidity {
+		return fmt.Errorf("uid validity of %s changed since the messages were fetched", a.config.folder())
+	}
+
+	switch a.config.After {
+	case AfterMarkRead:
+		err = c.UidStore(seqSet, goimap.FormatFlagsOp(goimap.AddFlags, true), []interface{}{goimap.SeenFlag}, nil)
+	case AfterMove:
+		// falls back to copying and expunging when the server doesn't support MOVE
+		err = c.UidMove(seqSet, a.config.MoveTo)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to %s messages: %w", a.config.After, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,309 @@
+package imap
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	goimap "github.com/emersion/go-imap"
+	"github.com/emersion/go-imap/backend"
+	"github.com/emersion/go-imap/backend/memory"
+	"github.com/emersion/go-imap/server"
+)
+
+// standInBackend is the in-memory backend of go-imap, with a uid validity the tests can change
+// and MOVE support, which the memory mailboxes lack
+type standInBackend struct {
+	*memory.Backend
+	uidValidity atomic.Uint32
+}
+
+func (b *standInBackend) Login(connInfo *goimap.ConnInfo, username, password string) (backend.User, error) {
+	user, err := b.Backend.Login(connInfo, username, password)
+	if err != nil {
+		return nil, err
+	}
+	return &standInUser{User: user, backend: b}, nil
+}
+
+type standInUser struct {
+	backend.User
+	backend *standInBackend
+}
+
+func (u *standInUser) GetMailbox(name string) (backend.Mailbox, error) {
+	mailbox, err := u.User.GetMailbox(name)
+	if err != nil {
+		return nil, err
+	}
+	return &standInMailbox{Mailbox: mailbox, backend: u.backend}, nil
+}
+
+type standInMailbox struct {
+	backend.Mailbox
+	backend *standInBackend
+}
+
+func (m *standInMailbox) Status(items []goimap.StatusItem) (*goimap.MailboxStatus, error) {
+	status, err := m.Mailbox.Status(items)
+	if err != nil {
+		return nil, err
+	}
+	status.UidValidity = m.backend.uidValidity.Load()
+	return status, nil
+}
+
+func (m *standInMailbox) MoveMessages(uid bool, seqSet *goimap.SeqSet, dest string) error {
+	if err := m.Mailbox.CopyMessages(uid, seqSet, dest); err != nil {
+		return err
+	}
+	if err := m.Mailbox.UpdateMessagesFlags(uid, seqSet, goimap.AddFlags, []string{goimap.DeletedFlag}); err != nil {
+		return err
+	}
+	return m.Mailbox.Expunge()
+}
+
+const (
+	// digest is delivered twice, as a multipart message whose HTML link differs from the text one
+	digest = "From: Weekly <newsletter@example.com>\r\n" +
+		"Subject: Weekly digest\r\n" +
+		"Date: Mon, 05 Oct 2026 08:00:00 +0000\r\n" +
+		"Message-ID: <digest-1@example.com>\r\n" +
+		"Content-Type: multipart/alternative; boundary=b\r\n" +
+		"\r\n" +
+		"--b\r\n" +
+		"Content-Type: text/plain\r\n" +
+		"\r\n" +
+		"View online: https://example.com/text\r\n" +
+		"--b\r\n" +
+		"Content-Type: text/html\r\n" +
+		"\r\n" +
+		"<p><a href=\"mailto:newsletter@example.com\">Reply</a> <a href=\"https://example.com/html\">View online</a></p>\r\n" +
+		"--b--\r\n"
+	// notice has no Message-ID
+	notice = "From: newsletter@example.com\r\n" +
+		"Subject: Maintenance notice\r\n" +
+		"Date: Tue, 06 Oct 2026 08:00:00 +0000\r\n" +
+		"Content-Type: text/plain\r\n" +
+		"\r\n" +
+		"Read more at https://example.com/maintenance.\r\n"
+	unrelated = "From: someone@example.org\r\n" +
+		"Subject: Weekly digest\r\n" +
+		"Date: Wed, 07 Oct 2026 08:00:00 +0000\r\n" +
+		"Message-ID: <unrelated@example.org>\r\n" +
+		"\r\n" +
+		"Hello\r\n"
+)
+
+// newStandIn serves the memory backend over TLS on a local port, with an Archive folder and the test messages
+// in the INBOX next to the backend's own message. the uids of the test messages are 7 to 10.
+func newStandIn(t *testing.T) (*standInBackend, *Config) {
+	t.Helper()
+
+	be := &standInBackend{Backend: memory.New()}
+	be.uidValidity.Store(1)
+
+	user, err := be.Backend.Login(nil, "username", "password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := user.CreateMailbox("Archive"); err != nil {
+		t.Fatal(err)
+	}
+	inbox, err := user.GetMailbox("INBOX")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, body := range []string{digest, notice, unrelated, digest} {
+		if err := inbox.CreateMessage(nil, time.Now(), bytes.NewBufferString(body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// borrows the certificate of httptest, which is valid for 127.0.0.1
+	certServer := httptest.NewTLSServer(nil)
+	cert := certServer.TLS.Certificates[0]
+	roots := x509.NewCertPool()
+	roots.AddCert(certServer.Certificate())
+	certServer.Close()
+
+	previous := tlsConfig
+	tlsConfig = &tls.Config{RootCAs: roots}
+	t.Cleanup(func() { tlsConfig = previous })
+
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{Certificates: []tls.Certificate{cert}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := server.New(be)
+	go s.Serve(listener)
+	t.Cleanup(func() { _ = s.Close() })
+
+	host, port, _ := net.SplitHostPort(listener.Addr().String())
+	portNumber, _ := strconv.Atoi(port)
+
+	return be, &Config{Host: host, Port: portNumber, Username: "username", Password: "password"}
+}
+
+// messageCount returns the number of messages in the folder, along with the number of seen ones
+func messageCount(t *testing.T, be *standInBackend, folder string) (int, int) {
+	t.Helper()
+
+	user, _ := be.Backend.Login(nil, "username", "password")
+	mailbox, err := user.GetMailbox(folder)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	messages := mailbox.(*memory.Mailbox).Messages
+	var seen int
+	for _, msg := range messages {
+		for _, flag := range msg.Flags {
+			if flag == goimap.SeenFlag {
+				seen++
+			}
+		}
+	}
+	return len(messages), seen
+}
+
+func TestFetch(t *testing.T) {
+	_, config := newStandIn(t)
+	config.From = "newsletter@example.com"
+
+	adapter, err := NewAdapter(config, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	contents, err := adapter.Fetch(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(contents) != 2 {
+		t.Fatalf("got %d contents, want the notice and a single digest", len(contents))
+	}
+
+	notice, digest := contents[0], contents[1]
+	if want := adapter.SourceID() + ":1.8"; notice.ID != want {
+		t.Errorf("notice ID = %q, want the uid validity and uid %q", notice.ID, want)
+	}
+	if notice.URL != "https://example.com/maintenance" {
+		t.Errorf("notice URL = %q, want the first link of the text", notice.URL)
+	}
+	if want := adapter.SourceID() + ":digest-1@example.com"; digest.ID != want {
+		t.Errorf("digest ID = %q, want %q", digest.ID, want)
+	}
+	if digest.URL != "https://example.com/html" {
+		t.Errorf("digest URL = %q, want the first http link of the HTML body", digest.URL)
+	}
+	if digest.Title != "Weekly digest" || digest.Author != "Weekly" {
+		t.Errorf("unexpected digest: %q by %q", digest.Title, digest.Author)
+	}
+	if uids := adapter.uids[digest.ID]; len(uids) != 2 {
+		t.Errorf("digest uids = %v, want both deliveries", uids)
+	}
+
+	config.Subject = "DIGEST"
+	contents, err = adapter.Fetch(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(contents) != 1 || contents[0].Title != "Weekly digest" {
+		t.Errorf("got %d contents matching the subject, want the digest", len(contents))
+	}
+}
+
+func TestAcknowledgeMarkRead(t *testing.T) {
+	be, config := newStandIn(t)
+	config.From = "newsletter@example.com"
+	config.After = AfterMarkRead
+
+	adapter, err := NewAdapter(config, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	contents, err := adapter.Fetch(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, seen := messageCount(t, be, "INBOX"); seen != 1 {
+		t.Fatalf("fetching flagged %d messages as seen", seen-1)
+	}
+
+	if err := adapter.Acknowledge(context.Background(), contents); err != nil {
+		t.Fatal(err)
+	}
+	if _, seen := messageCount(t, be, "INBOX"); seen != 4 {
+		t.Errorf("%d messages seen, want the backend's one and the 3 acknowledged", seen)
+	}
+
+	// the seen messages aren't fetched again
+	contents, err = adapter.Fetch(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(contents) != 0 {
+		t.Errorf("got %d contents after acknowledging them", len(contents))
+	}
+}
+
+func TestAcknowledgeMove(t *testing.T) {
+	be, config := newStandIn(t)
+	config.From = "newsletter@example.com"
+	config.After = AfterMove
+	config.MoveTo = "Archive"
+
+	adapter, err := NewAdapter(config, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	contents, err := adapter.Fetch(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := adapter.Acknowledge(context.Background(), contents); err != nil {
+		t.Fatal(err)
+	}
+
+	if count, _ := messageCount(t, be, "INBOX"); count != 2 {
+		t.Errorf("%d messages left in the INBOX, want the backend's one and the unrelated one", count)
+	}
+	if count, _ := messageCount(t, be, "Archive"); count != 3 {
+		t.Errorf("%d messages moved, want 3", count)
+	}
+}
+
+func TestAcknowledgeUIDValidityChange(t *testing.T) {
+	be, config := newStandIn(t)
+	config.From = "newsletter@example.com"
+	config.After = AfterMarkRead
+
+	adapter, err := NewAdapter(config, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	contents, err := adapter.Fetch(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the folder was recreated, the uids may point to other messages
+	be.uidValidity.Store(2)
+	if err := adapter.Acknowledge(context.Background(), contents); err == nil {
+		t.Error("expected an error once the uid validity changed")
+	}
+	if _, seen := messageCount(t, be, "INBOX"); seen != 1 {
+		t.Errorf("flagged %d messages with stale uids", seen-1)
+	}
+}
@@ -0,0 +1,101 @@
+package imap
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/ryansiau/KeepUpdated/go/model"
+)
+
+// What happens to the messages once they've been processed
+const (
+	AfterMarkRead = "mark_read"
+	AfterMove     = "move"
+)
+
+var validAfter = []string{"", AfterMarkRead, AfterMove}
+
+// Defaults used when the fields aren't configured
+const (
+	defaultPort        = 993
+	defaultFolder      = "INBOX"
+	defaultMaxMessages = 20
+)
+
+// Config represents the configuration for an IMAP mailbox source
+type Config struct {
+	Host string `yaml:"host" mapstructure:"host"`
+	// Port is 993 by default, or the plain port of the server when StartTLS is set
+	Port     int    `yaml:"port" mapstructure:"port"`
+	Username string `yaml:"username" mapstructure:"username"`
+	// Password is usually an app password, as mail providers don't accept the account password over IMAP
+	Password string `yaml:"password" mapstructure:"password"`
+	// StartTLS upgrades a plain connection instead of connecting over TLS directly
+	StartTLS bool `yaml:"starttls" mapstructure:"starttls"`
+
+	// Folder is read for messages, INBOX by default
+	Folder string `yaml:"folder" mapstructure:"folder"`
+	// From and Subject only keep the messages whose header contains them, case-insensitively
+	From    string `yaml:"from" mapstructure:"from"`
+	Subject string `yaml:"subject" mapstructure:"subject"`
+	// MaxMessages is the number of the latest matching messages read on every execution, 20 by default
+	MaxMessages int `yaml:"max_messages" mapstructure:"max_messages"`
+
+	// After is mark_read or move to flag processed messages as seen or move them to MoveTo.
+	// they're left untouched by default.
+	After  string `yaml:"after" mapstructure:"after"`
+	MoveTo string `yaml:"move_to" mapstructure:"move_to"`
+}
+
+// Validate validates the IMAP source configuration
+func (c *Config) Validate() error {
+	if c.Host == "" {
+		return fmt.Errorf("host is required")
+	}
+	if c.Username == "" || c.Password == "" {
+		return fmt.Errorf("username and password are required")
+	}
+	if c.Port < 0 || c.Port > 65535 {
+		return fmt.Errorf("invalid port: %d", c.Port)
+	}
+	if c.MaxMessages < 0 {
+		return fmt.Errorf("max_messages can't be negative")
+	}
+	if !slices.Contains(validAfter, c.After) {
+		return fmt.Errorf("invalid after: %s", c.After)
+	}
+	if c.After == AfterMove && c.MoveTo == "" {
+		return fmt.Errorf("move_to is required to move messages")
+	}
+	if c.After != AfterMove && c.MoveTo != "" {
+		return fmt.Errorf("move_to requires after to be move")
+	}
+	return nil
+}
+
+func (c *Config) IsCrawler() {}
+
+func (c *Config) Build(name string) (model.Source, error) {
+	return NewAdapter(c, name)
+}
+
+func (c *Config) port() int {
+	if c.Port == 0 {
+		return defaultPort
+	}
+	return c.Port
+}
+
+func (c *Config) folder() string {
+	if c.Folder == "" {
+		return defaultFolder
+	}
+	return c.Folder
+}
+
+func (c *Config) maxMessages() int {
+	if c.MaxMessages == 0 {
+		return defaultMaxMessages
+	}
+	return c.MaxMessages
+}
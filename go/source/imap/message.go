@@ -0,0 +1,159 @@
+package imap
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	goimap "github.com/emersion/go-imap"
+	"github.com/emersion/go-message"
+	_ "github.com/emersion/go-message/charset"
+	"github.com/emersion/go-message/mail"
+	"golang.org/x/net/html"
+
+	"github.com/ryansiau/KeepUpdated/go/model"
+	"github.com/ryansiau/KeepUpdated/go/pkg/htmltext"
+)
+
+var linkRegex = regexp.MustCompile(`https?://[^\s<>"()]+`)
+
+// parseMessage converts a fetched message into a content
+func (a *Adapter) parseMessage(msg *goimap.Message, body io.Reader) (model.Content, error) {
+	if body == nil {
+		return model.Content{}, fmt.Errorf("the server didn't return the body")
+	}
+
+	mr, err := mail.CreateReader(body)
+	if err != nil && !message.IsUnknownCharset(err) {
+		return model.Content{}, err
+	}
+
+	text, htmlBody, err := readBody(mr)
+	if err != nil {
+		return model.Content{}, err
+	}
+
+	subject, _ := mr.Header.Subject()
+
+	// messages without a Message-ID are told apart by their uid, which is only unique along with the uid validity
+	messageID, _ := mr.Header.MessageID()
+	id := messageID
+	if id == "" {
+		id = fmt.Sprintf("%d.%d", a.uidValidity, msg.Uid)
+	}
+
+	publishedAt, err := mr.Header.Date()
+	if err != nil || publishedAt.IsZero() {
+		publishedAt = msg.InternalDate
+	}
+
+	var author, fromAddress string
+	if from, err := mr.Header.AddressList("From"); err == nil && len(from) > 0 {
+		fromAddress = from[0].Address
+		author = from[0].Name
+		if author == "" {
+			author = fromAddress
+		}
+	}
+
+	description := strings.TrimSpace(text)
+	link := firstTextLink(text)
+	if htmlBody != "" {
+		if description == "" {
+			description = htmltext.ToText(htmlBody)
+		}
+		// the links of HTML bodies are more reliable than the ones of their plain text alternative
+		if htmlLink := firstHTMLLink(htmlBody); htmlLink != "" {
+			link = htmlLink
+		}
+	}
+
+	return model.Content{
+		ID:          a.SourceID() + ":" + id,
+		SourceID:    a.SourceID(),
+		Title:       subject,
+		Description: description,
+		URL:         link,
+		Author:      author,
+		Platform:    "IMAP",
+		PublishedAt: publishedAt,
+		UpdatedAt:   time.Now(),
+		Metadata: map[string]interface{}{
+			"message_id": messageID,
+			"from":       fromAddress,
+			"folder":     a.config.folder(),
+			"uid":        msg.Uid,
+		},
+	}, nil
+}
+
+// readBody returns the first text/plain and text/html parts of the message, attachments are skipped
+func readBody(mr *mail.Reader) (text, htmlBody string, err error) {
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil && !message.IsUnknownCharset(err) {
+			return "", "", err
+		}
+
+		header, ok := part.Header.(*mail.InlineHeader)
+		if !ok {
+			continue
+		}
+
+		contentType, _, _ := header.ContentType()
+		if contentType == "" {
+			contentType = "text/plain"
+		}
+		if contentType != "text/plain" && contentType != "text/html" {
+			continue
+		}
+
+		b, err := io.ReadAll(part.Body)
+		if err != nil {
+			return "", "", err
+		}
+
+		if contentType == "text/plain" && text == "" {
+			text = string(b)
+		} else if contentType == "text/html" && htmlBody == "" {
+			htmlBody = string(b)
+		}
+	}
+
+	return text, htmlBody, nil
+}
+
+// firstHTMLLink returns the first http(s) link of the HTML body
+func firstHTMLLink(s string) string {
+	tokenizer := html.NewTokenizer(strings.NewReader(s))
+	for {
+		tokenType := tokenizer.Next()
+		if tokenType == html.ErrorToken {
+			return ""
+		}
+		if tokenType != html.StartTagToken {
+			continue
+		}
+
+		token := tokenizer.Token()
+		if token.Data != "a" {
+			continue
+		}
+		for _, attr := range token.Attr {
+			href := strings.TrimSpace(attr.Val)
+			if attr.Key == "href" && (strings.HasPrefix(href, "https://") || strings.HasPrefix(href, "http://")) {
+				return href
+			}
+		}
+	}
+}
+
+// firstTextLink returns the first http(s) link of the plain text body
+func firstTextLink(s string) string {
+	return strings.TrimRight(linkRegex.FindString(s), ".,;:!?")
+}
@@ -0,0 +1,135 @@
+// Package json_api follows arbitrary REST APIs, mapping their JSON responses into contents with JMESPath.
+package json_api
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"resty.dev/v3"
+
+	"github.com/ryansiau/KeepUpdated/go/common"
+	"github.com/ryansiau/KeepUpdated/go/model"
+)
+
+// Adapter implements the Source interface for JSON APIs
+type Adapter struct {
+	client *resty.Client
+	config *Config
+	name   string
+}
+
+// NewAdapter creates a new JSON API adapter with configuration
+func NewAdapter(config *Config, name string) (*Adapter, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	if name == "" {
+		name = "JSON API: " + config.URL
+	}
+
+	client := resty.New().
+		SetTimeout(30*time.Second).
+		SetHeader("User-Agent", common.HTTPClientUserAgent).
+		SetHeader("Accept", "application/json")
+
+	return &Adapter{
+		client: client,
+		config: config,
+		name:   name,
+	}, nil
+}
+
+// Name returns the name of the source
+func (a *Adapter) Name() string {
+	return a.name
+}
+
+// Type returns the platform type
+func (a *Adapter) Type() string {
+	return "json_api"
+}
+
+// SourceID returns the identifier of the source.
+// an endpoint answers many requests, so the method, the body and the mapping of the items are part of it.
+func (a *Adapter) SourceID() string {
+	body, _ := json.Marshal(a.config.Body)
+	sum := sha256.Sum256([]byte(a.config.method() + "\n" + string(body) + "\n" + a.config.Items + "\n" + a.config.ID))
+	return fmt.Sprintf("JSONAPI:%s:%s", a.config.URL, hex.EncodeToString(sum[:8]))
+}
+
+// Fetch requests the API and maps the items of the response
+func (a *Adapter) Fetch(ctx context.Context) ([]model.Content, error) {
+	req := a.client.R().
+		SetContext(ctx).
+		SetHeaders(a.config.Headers)
+
+	switch body := a.config.Body.(type) {
+	case nil:
+	case string:
+		req.SetBody(body)
+	default:
+		encoded, err := json.Marshal(normalize(body))
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode body: %w", err)
+		}
+		req.SetHeader("Content-Type", "application/json").SetBody(encoded)
+	}
+
+	resp, err := req.Execute(a.config.method(), a.config.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode() < 200 || resp.StatusCode() > 299 {
+		return nil, fmt.Errorf("status code: %d, body: %s", resp.StatusCode(), resp.String())
+	}
+
+	var data interface{}
+	if err := json.Unmarshal(resp.Bytes(), &data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	contents, err := a.config.Mapping.Contents(data, a.SourceID(), "JSON API", map[string]struct{}{})
+	if err != nil {
+		return nil, err
+	}
+
+	// newest first, items without a date keep the order of the response
+	slices.SortStableFunc(contents, func(a, b model.Content) int {
+		return b.PublishedAt.Compare(a.PublishedAt)
+	})
+
+	return contents, nil
+}
+
+// normalize converts the map[interface{}]interface{} that YAML may decode into JSON-encodable maps
+func normalize(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			m[fmt.Sprint(key)] = normalize(item)
+		}
+		return m
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			m[key] = normalize(item)
+		}
+		return m
+	case []interface{}:
+		list := make([]interface{}, len(v))
+		for i, item := range v {
+			list[i] = normalize(item)
+		}
+		return list
+	default:
+		return v
+	}
+}
@@ -0,0 +1,50 @@
+package json_api
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/ryansiau/KeepUpdated/go/model"
+)
+
+// Config represents the configuration for a JSON API source
+type Config struct {
+	URL string `yaml:"url" mapstructure:"url"`
+	// Method is GET by default
+	Method  string            `yaml:"method" mapstructure:"method"`
+	Headers map[string]string `yaml:"headers" mapstructure:"headers"`
+	// Body is sent as is when it's a string, encoded as JSON otherwise
+	Body interface{} `yaml:"body" mapstructure:"body"`
+
+	Mapping `yaml:",inline" mapstructure:",squash"`
+}
+
+// Validate validates the JSON API source configuration
+func (c *Config) Validate() error {
+	u, err := url.Parse(c.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("url has to be an absolute http(s) URL: %s", c.URL)
+	}
+
+	if !slices.Contains([]string{http.MethodGet, http.MethodPost, http.MethodPut}, c.method()) {
+		return fmt.Errorf("invalid method: %s", c.Method)
+	}
+
+	return c.Mapping.Validate()
+}
+
+func (c *Config) IsCrawler() {}
+
+func (c *Config) Build(name string) (model.Source, error) {
+	return NewAdapter(c, name)
+}
+
+func (c *Config) method() string {
+	if c.Method == "" {
+		return http.MethodGet
+	}
+	return strings.ToUpper(c.Method)
+}
@@ -0,0 +1,196 @@
+package json_api
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jmespath/go-jmespath"
+
+	"github.com/ryansiau/KeepUpdated/go/model"
+	generic_rss "github.com/ryansiau/KeepUpdated/go/source/generic-rss"
+)
+
+// Mapping turns a JSON document into contents with JMESPath expressions, e.g. items "data.posts[]" and id "id".
+// the fields are evaluated against every item, link being the URL of the content.
+type Mapping struct {
+	Items       string `yaml:"items" mapstructure:"items"`
+	ID          string `yaml:"id" mapstructure:"id"`
+	Title       string `yaml:"title" mapstructure:"title"`
+	URL         string `yaml:"link" mapstructure:"link"`
+	Author      string `yaml:"author" mapstructure:"author"`
+	Date        string `yaml:"date" mapstructure:"date"`
+	Description string `yaml:"description" mapstructure:"description"`
+	// DateFormat is the Go layout of the date. RFC 3339, RSS dates and unix timestamps are understood without it.
+	DateFormat string `yaml:"date_format" mapstructure:"date_format"`
+	// Metadata maps metadata keys to expressions, for the metadata filter to match on
+	Metadata map[string]string `yaml:"metadata" mapstructure:"metadata"`
+}
+
+// Validate makes sure the expressions compile
+func (m *Mapping) Validate() error {
+	if m.ID == "" {
+		return fmt.Errorf("id is required")
+	}
+
+	expressions := map[string]string{
+		"items":       m.Items,
+		"id":          m.ID,
+		"title":       m.Title,
+		"link":        m.URL,
+		"author":      m.Author,
+		"date":        m.Date,
+		"description": m.Description,
+	}
+	for key, expression := range m.Metadata {
+		expressions["metadata."+key] = expression
+	}
+
+	for field, expression := range expressions {
+		if expression == "" {
+			continue
+		}
+		if _, err := jmespath.Compile(expression); err != nil {
+			return fmt.Errorf("invalid %s expression %q: %w", field, expression, err)
+		}
+	}
+
+	return nil
+}
+
+// Contents maps the items of a decoded JSON document into contents. the IDs are prefixed with sourceID.
+// seen collects the IDs of the mapped items, an item whose ID was seen already is skipped, e.g. a paginated
+// response repeating an item. a nil seen keeps every item.
+func (m *Mapping) Contents(data interface{}, sourceID, platform string, seen map[string]struct{}) ([]model.Content, error) {
+	items := data
+	if m.Items != "" {
+		var err error
+		items, err = jmespath.Search(m.Items, data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to evaluate items: %w", err)
+		}
+	}
+
+	list, ok := items.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("items didn't evaluate to an array, got %T", items)
+	}
+
+	now := time.Now()
+
+	var contents []model.Content
+	for _, item := range list {
+		id := m.text(m.ID, item)
+		if id == "" {
+			continue
+		}
+		if seen != nil {
+			if _, ok := seen[id]; ok {
+				continue
+			}
+			seen[id] = struct{}{}
+		}
+
+		title := m.text(m.Title, item)
+		if title == "" {
+			title = id
+		}
+
+		publishedAt := now
+		if m.Date != "" {
+			if date, ok := m.date(item); ok {
+				publishedAt = date
+			}
+		}
+
+		metadata := map[string]interface{}{}
+		for key, expression := range m.Metadata {
+			value, err := jmespath.Search(expression, item)
+			if err == nil && value != nil {
+				metadata[key] = value
+			}
+		}
+
+		contents = append(contents, model.Content{
+			ID:          sourceID + ":" + id,
+			SourceID:    sourceID,
+			Title:       title,
+			Description: m.text(m.Description, item),
+			URL:         m.text(m.URL, item),
+			Author:      m.text(m.Author, item),
+			Platform:    platform,
+			PublishedAt: publishedAt,
+			UpdatedAt:   now,
+			Metadata:    metadata,
+		})
+	}
+
+	return contents, nil
+}
+
+// text evaluates the expression into a string, empty when it's not set or not found
+func (m *Mapping) text(expression string, item interface{}) string {
+	if expression == "" {
+		return ""
+	}
+
+	value, err := jmespath.Search(expression, item)
+	if err != nil || value == nil {
+		return ""
+	}
+
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		// JSON numbers are decoded as float64, IDs are usually integers
+		if v == math.Trunc(v) && math.Abs(v) < 1e15 {
+			return strconv.FormatInt(int64(v), 10)
+		}
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// date evaluates the date expression, accepting strings and unix timestamps in seconds or milliseconds
+func (m *Mapping) date(item interface{}) (time.Time, bool) {
+	value, err := jmespath.Search(m.Date, item)
+	if err != nil || value == nil {
+		return time.Time{}, false
+	}
+
+	if timestamp, ok := value.(float64); ok {
+		if timestamp > 1e12 {
+			return time.UnixMilli(int64(timestamp)), true
+		}
+		return time.Unix(int64(timestamp), 0), true
+	}
+
+	s, ok := value.(string)
+	if !ok {
+		return time.Time{}, false
+	}
+	s = strings.TrimSpace(s)
+
+	if m.DateFormat != "" {
+		date, err := time.Parse(m.DateFormat, s)
+		return date, err == nil
+	}
+
+	if date, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return date, true
+	}
+	if date, err := generic_rss.ParseDate(s); err == nil {
+		return date, true
+	}
+	if timestamp, err := strconv.ParseInt(s, 10, 64); err == nil {
+		if timestamp > 1e12 {
+			return time.UnixMilli(timestamp), true
+		}
+		return time.Unix(timestamp, 0), true
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,268 @@
+package mastodon
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"resty.dev/v3"
+
+	"github.com/ryansiau/KeepUpdated/go/common"
+	"github.com/ryansiau/KeepUpdated/go/model"
+	"github.com/ryansiau/KeepUpdated/go/pkg/htmltext"
+)
+
+// statusLimit is the number of statuses requested on every fetch, the maximum of the API
+const statusLimit = 40
+
+// Keys of the state stored between executions
+const (
+	stateHost      = "host"
+	stateAccountID = "account_id"
+)
+
+// Adapter implements the Source interface for Mastodon-compatible instances
+type Adapter struct {
+	client *resty.Client
+	config *Config
+	name   string
+
+	// host and accountID are resolved once through WebFinger, then kept in the state
+	host      string
+	accountID string
+}
+
+var _ model.StatefulSource = (*Adapter)(nil)
+
+// NewAdapter creates a new Mastodon adapter with configuration
+func NewAdapter(config *Config, name string) (*Adapter, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	if name == "" {
+		name = "Mastodon: @" + config.acct()
+		if config.Tag != "" {
+			name = fmt.Sprintf("Mastodon: #%s on %s", config.tag(), config.instanceHost())
+		}
+	}
+
+	client := resty.New().
+		SetTimeout(30*time.Second).
+		SetHeader("User-Agent", common.HTTPClientUserAgent)
+
+	return &Adapter{
+		client: client,
+		config: config,
+		name:   name,
+	}, nil
+}
+
+// Name returns the name of the source
+func (a *Adapter) Name() string {
+	return a.name
+}
+
+// Type returns the platform type
+func (a *Adapter) Type() string {
+	return "mastodon"
+}
+
+// SourceID returns the identifier of the source
+func (a *Adapter) SourceID() string {
+	return "Mastodon:" + a.feedKey()
+}
+
+// feedKey identifies the account or the tag timeline of the source
+func (a *Adapter) feedKey() string {
+	if a.config.Tag != "" {
+		return fmt.Sprintf("#%s@%s", strings.ToLower(a.config.tag()), a.config.instanceHost())
+	}
+	return "@" + strings.ToLower(a.config.acct())
+}
+
+// LoadState restores the resolved instance and account ID
+func (a *Adapter) LoadState(state map[string]string) {
+	a.host = state[stateHost]
+	a.accountID = state[stateAccountID]
+}
+
+// State returns the resolved instance and account ID, to skip WebFinger on the next execution
+func (a *Adapter) State() map[string]string {
+	if a.accountID == "" {
+		return nil
+	}
+	return map[string]string{
+		stateHost:      a.host,
+		stateAccountID: a.accountID,
+	}
+}
+
+// request creates a request to the instance API, authenticated when an access token is configured
+func (a *Adapter) request(ctx context.Context) *resty.Request {
+	req := a.client.R().
+		SetContext(ctx).
+		SetForceResponseContentType("application/json")
+	if a.config.AccessToken != "" {
+		req.SetAuthToken(a.config.AccessToken)
+	}
+	return req
+}
+
+// Fetch retrieves the latest statuses of the account or the tag timeline
+func (a *Adapter) Fetch(ctx context.Context) ([]model.Content, error) {
+	var statuses []Status
+	var err error
+
+	if a.config.Tag != "" {
+		statuses, err = a.fetchStatuses(ctx, fmt.Sprintf("%s://%s/api/v1/timelines/tag/%s", scheme, a.config.instanceHost(), url.PathEscape(a.config.tag())), nil)
+	} else {
+		if a.accountID == "" {
+			a.host, a.accountID, err = a.resolveAccount(ctx, a.config.acct())
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		params := map[string]string{}
+		if a.config.ExcludeReplies {
+			params["exclude_replies"] = "true"
+		}
+		if a.config.ExcludeBoosts {
+			params["exclude_reblogs"] = "true"
+		}
+
+		statuses, err = a.fetchStatuses(ctx, fmt.Sprintf("%s://%s/api/v1/accounts/%s/statuses", scheme, a.host, url.PathEscape(a.accountID)), params)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var contents []model.Content
+	for _, status := range statuses {
+		// the tag timeline has no exclude parameters, and older servers ignore them
+		if a.config.ExcludeBoosts && status.Reblog != nil {
+			continue
+		}
+		if a.config.ExcludeReplies && status.InReplyToID != "" {
+			continue
+		}
+
+		contents = append(contents, a.newContent(status))
+	}
+
+	return contents, nil
+}
+
+// fetchStatuses requests a list of statuses, which the API returns newest first
+func (a *Adapter) fetchStatuses(ctx context.Context, endpoint string, params map[string]string) ([]Status, error) {
+	var statuses []Status
+
+	resp, err := a.request(ctx).
+		SetQueryParam("limit", fmt.Sprint(statusLimit)).
+		SetQueryParams(params).
+		SetResult(&statuses).
+		Get(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode() != 200 {
+		return nil, fmt.Errorf("status code: %d, body: %s", resp.StatusCode(), resp.String())
+	}
+
+	return statuses, nil
+}
+
+func (a *Adapter) newContent(status Status) model.Content {
+	// a boost shows the boosted status, credited to its original author
+	post := status
+	isBoost := status.Reblog != nil
+	if isBoost {
+		post = *status.Reblog
+	}
+
+	title := fmt.Sprintf("Post by @%s", post.Account.Acct)
+	switch {
+	case isBoost:
+		title = fmt.Sprintf("Boost by @%s", status.Account.Acct)
+	case post.InReplyToID != "":
+		title = fmt.Sprintf("Reply by @%s", post.Account.Acct)
+	}
+
+	description := htmltext.ToText(post.Content)
+	if post.SpoilerText != "" {
+		description = fmt.Sprintf("CW: %s\n\n%s", post.SpoilerText, description)
+	}
+
+	link := post.URL
+	if link == "" {
+		link = post.URI
+	}
+
+	var tags []string
+	for _, tag := range post.Tags {
+		tags = append(tags, tag.Name)
+	}
+
+	var media []string
+	for _, attachment := range post.MediaAttachments {
+		media = append(media, attachment.URL)
+	}
+
+	return model.Content{
+		ID:          fmt.Sprintf("mastodon:%s:%s", a.feedKey(), status.ID),
+		SourceID:    a.SourceID(),
+		Title:       title,
+		Description: description,
+		URL:         link,
+		Author:      "@" + post.Account.Acct,
+		Platform:    "Mastodon",
+		PublishedAt: status.CreatedAt,
+		UpdatedAt:   time.Now(),
+		Metadata: map[string]interface{}{
+			"status_id":        status.ID,
+			"uri":              post.URI,
+			"author_handle":    post.Account.Acct,
+			"is_boost":         isBoost,
+			"is_reply":         post.InReplyToID != "",
+			"sensitive":        post.Sensitive,
+			"replies_count":    post.RepliesCount,
+			"reblogs_count":    post.ReblogsCount,
+			"favourites_count": post.FavouritesCount,
+			"tags":             strings.Join(tags, ","),
+			"media":            strings.Join(media, ","),
+		},
+	}
+}
+
+// Status is a status of the Mastodon API
+type Status struct {
+	ID              string    `json:"id"`
+	URI             string    `json:"uri"`
+	URL             string    `json:"url"`
+	CreatedAt       time.Time `json:"created_at"`
+	Content         string    `json:"content"`
+	SpoilerText     string    `json:"spoiler_text"`
+	Sensitive       bool      `json:"sensitive"`
+	InReplyToID     string    `json:"in_reply_to_id"`
+	Reblog          *Status   `json:"reblog"`
+	RepliesCount    int       `json:"replies_count"`
+	ReblogsCount    int       `json:"reblogs_count"`
+	FavouritesCount int       `json:"favourites_count"`
+	Account         struct {
+		Acct        string `json:"acct"`
+		DisplayName string `json:"display_name"`
+		URL         string `json:"url"`
+	} `json:"account"`
+	Tags []struct {
+		Name string `json:"name"`
+	} `json:"tags"`
+	MediaAttachments []struct {
+		Type        string `json:"type"`
+		URL         string `json:"url"`
+		Description string `json:"description"`
+	} `json:"media_attachments"`
+}
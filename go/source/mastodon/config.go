@@ -0,0 +1,68 @@
+package mastodon
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/ryansiau/KeepUpdated/go/model"
+)
+
+var (
+	accountRegex = regexp.MustCompile(`^@?[A-Za-z0-9_.-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}$`)
+	tagRegex     = regexp.MustCompile(`^#?[\p{L}\p{N}_]+$`)
+)
+
+// Config represents the configuration for a Mastodon source
+type Config struct {
+	// Account is the full handle of the user, e.g. @Gargron@mastodon.social, resolved through WebFinger
+	Account string `yaml:"account" mapstructure:"account"`
+	// Tag reads the hashtag timeline of Instance instead, e.g. golang
+	Tag      string `yaml:"tag" mapstructure:"tag"`
+	Instance string `yaml:"instance" mapstructure:"instance"`
+	// AccessToken is only needed by instances that don't expose their timelines publicly
+	AccessToken    string `yaml:"access_token" mapstructure:"access_token"`
+	ExcludeReplies bool   `yaml:"exclude_replies" mapstructure:"exclude_replies"`
+	ExcludeBoosts  bool   `yaml:"exclude_boosts" mapstructure:"exclude_boosts"`
+}
+
+// Validate validates the Mastodon source configuration
+func (c *Config) Validate() error {
+	if (c.Account == "") == (c.Tag == "") {
+		return fmt.Errorf("exactly one of account or tag is required")
+	}
+	if c.Account != "" && !accountRegex.MatchString(c.Account) {
+		return fmt.Errorf("account has to be in the @user@instance format: %s", c.Account)
+	}
+	if c.Tag != "" {
+		if !tagRegex.MatchString(c.Tag) {
+			return fmt.Errorf("invalid tag: %s", c.Tag)
+		}
+		if c.Instance == "" {
+			return fmt.Errorf("instance is required to read a tag timeline")
+		}
+	}
+	return nil
+}
+
+func (c *Config) IsCrawler() {}
+
+func (c *Config) Build(name string) (model.Source, error) {
+	return NewAdapter(c, name)
+}
+
+// acct returns the account without the leading @, e.g. Gargron@mastodon.social
+func (c *Config) acct() string {
+	return strings.TrimPrefix(c.Account, "@")
+}
+
+// tag returns the hashtag without the leading #
+func (c *Config) tag() string {
+	return strings.TrimPrefix(c.Tag, "#")
+}
+
+// instanceHost returns the host of the configured instance, which may be given as a URL
+func (c *Config) instanceHost() string {
+	host := strings.TrimPrefix(strings.TrimPrefix(c.Instance, "https://"), "http://")
+	return strings.TrimSuffix(host, "/")
+}
@@ -0,0 +1,80 @@
+package mastodon
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// scheme is the scheme of the requests to the instances
+const scheme = "https"
+
+// resolveAccount finds the instance hosting acct through WebFinger, and the ID of the account on it.
+// the domain of a handle may delegate to another host, e.g. user@example.com served by social.example.com.
+func (a *Adapter) resolveAccount(ctx context.Context, acct string) (host string, accountID string, err error) {
+	username, domain, _ := strings.Cut(acct, "@")
+
+	var finger struct {
+		Subject string `json:"subject"`
+		Links   []struct {
+			Rel  string `json:"rel"`
+			Type string `json:"type"`
+			Href string `json:"href"`
+		} `json:"links"`
+	}
+
+	resp, err := a.client.R().
+		SetContext(ctx).
+		SetQueryParam("resource", "acct:"+acct).
+		SetResult(&finger).
+		SetForceResponseContentType("application/json").
+		Get(fmt.Sprintf("%s://%s/.well-known/webfinger", scheme, domain))
+	if err != nil {
+		return "", "", err
+	}
+
+	if resp.StatusCode() != 200 {
+		return "", "", fmt.Errorf("failed to resolve %s through WebFinger, status code: %d", acct, resp.StatusCode())
+	}
+
+	for _, link := range finger.Links {
+		if link.Rel != "self" || !strings.Contains(link.Type, "activity+json") {
+			continue
+		}
+		if u, err := url.Parse(link.Href); err == nil && u.Host != "" {
+			host = u.Host
+			break
+		}
+	}
+	if host == "" {
+		return "", "", fmt.Errorf("WebFinger didn't return the ActivityPub actor of %s", acct)
+	}
+
+	// the subject holds the canonical handle, which is what the hosting instance knows the user by
+	if subject, found := strings.CutPrefix(finger.Subject, "acct:"); found {
+		username, _, _ = strings.Cut(subject, "@")
+	}
+
+	var account struct {
+		ID string `json:"id"`
+	}
+
+	resp, err = a.request(ctx).
+		SetQueryParam("acct", username).
+		SetResult(&account).
+		Get(fmt.Sprintf("%s://%s/api/v1/accounts/lookup", scheme, host))
+	if err != nil {
+		return "", "", err
+	}
+
+	if resp.StatusCode() != 200 {
+		return "", "", fmt.Errorf("failed to look up %s on %s, status code: %d, body: %s", username, host, resp.StatusCode(), resp.String())
+	}
+
+	if account.ID == "" {
+		return "", "", fmt.Errorf("account %s not found on %s", username, host)
+	}
+
+	return host, account.ID, nil
+}
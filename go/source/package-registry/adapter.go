@@ -0,0 +1,152 @@
+package package_registry
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"resty.dev/v3"
+
+	"github.com/ryansiau/KeepUpdated/go/common"
+	"github.com/ryansiau/KeepUpdated/go/model"
+)
+
+// maxVersions is the number of latest versions returned on every fetch
+const maxVersions = 50
+
+// Version is a published version of a package
+type Version struct {
+	Version     string
+	PublishedAt time.Time
+	Yanked      bool
+	Deprecated  bool
+	URL         string
+}
+
+// Adapter implements the Source interface for package registries
+type Adapter struct {
+	client *resty.Client
+	config *Config
+	name   string
+}
+
+// NewAdapter creates a new package registry adapter with configuration
+func NewAdapter(config *Config, name string) (*Adapter, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	if name == "" {
+		name = fmt.Sprintf("%s: %s", config.Ecosystem, config.Package)
+	}
+
+	client := resty.New().
+		SetTimeout(30*time.Second).
+		SetHeader("User-Agent", common.HTTPClientUserAgent)
+
+	return &Adapter{
+		client: client,
+		config: config,
+		name:   name,
+	}, nil
+}
+
+// Name returns the name of the source
+func (a *Adapter) Name() string {
+	return a.name
+}
+
+// Type returns the platform type
+func (a *Adapter) Type() string {
+	return "package"
+}
+
+// SourceID returns the identifier of the source
+func (a *Adapter) SourceID() string {
+	return fmt.Sprintf("Package:%s:%s", a.config.Ecosystem, a.config.Package)
+}
+
+// FetchVersions lists the versions of the package, in no particular order
+func (a *Adapter) FetchVersions(ctx context.Context) ([]Version, error) {
+	switch a.config.Ecosystem {
+	case EcosystemNPM:
+		return a.fetchNPM(ctx)
+	case EcosystemPyPI:
+		return a.fetchPyPI(ctx)
+	case EcosystemCrates:
+		return a.fetchCrates(ctx)
+	case EcosystemGoProxy:
+		return a.fetchGoProxy(ctx)
+	case EcosystemRubyGems:
+		return a.fetchRubyGems(ctx)
+	case EcosystemMavenCentral:
+		return a.fetchMavenCentral(ctx)
+	default:
+		return nil, fmt.Errorf("unsupported ecosystem: %s", a.config.Ecosystem)
+	}
+}
+
+// Fetch retrieves the latest versions of the package, one content per version
+func (a *Adapter) Fetch(ctx context.Context) ([]model.Content, error) {
+	versions, err := a.FetchVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// newest first
+	slices.SortStableFunc(versions, func(a, b Version) int {
+		return b.PublishedAt.Compare(a.PublishedAt)
+	})
+
+	var contents []model.Content
+	for _, version := range versions {
+		prerelease := isPrerelease(a.config.Ecosystem, version.Version)
+		if prerelease && a.config.IgnorePrereleases {
+			continue
+		}
+
+		contents = append(contents, model.Content{
+			ID:          fmt.Sprintf("%s:%s@%s", a.config.Ecosystem, a.config.Package, version.Version),
+			SourceID:    a.SourceID(),
+			Title:       fmt.Sprintf("%s %s", a.config.Package, version.Version),
+			URL:         version.URL,
+			Author:      a.config.Package,
+			Platform:    a.config.Ecosystem,
+			PublishedAt: version.PublishedAt,
+			UpdatedAt:   time.Now(),
+			Metadata: map[string]interface{}{
+				"ecosystem":  a.config.Ecosystem,
+				"package":    a.config.Package,
+				"version":    version.Version,
+				"prerelease": prerelease,
+				"yanked":     version.Yanked,
+				"deprecated": version.Deprecated,
+			},
+		})
+
+		if len(contents) == maxVersions {
+			break
+		}
+	}
+
+	return contents, nil
+}
+
+// get requests url and decodes the JSON response into result
+func (a *Adapter) get(ctx context.Context, url string, result interface{}) error {
+	resp, err := a.client.R().
+		SetContext(ctx).
+		SetResult(result).
+		SetForceResponseContentType("application/json").
+		Get(url)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode() != 200 {
+		return fmt.Errorf("status code: %d, body: %s", resp.StatusCode(), resp.String())
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package package_registry
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/ryansiau/KeepUpdated/go/model"
+)
+
+// Supported ecosystems
+const (
+	EcosystemNPM          = "npm"
+	EcosystemPyPI         = "pypi"
+	EcosystemCrates       = "crates"
+	EcosystemGoProxy      = "go-module-proxy"
+	EcosystemRubyGems     = "rubygems"
+	EcosystemMavenCentral = "maven-central"
+)
+
+var validEcosystems = []string{
+	EcosystemNPM,
+	EcosystemPyPI,
+	EcosystemCrates,
+	EcosystemGoProxy,
+	EcosystemRubyGems,
+	EcosystemMavenCentral,
+}
+
+// Config represents the configuration for a package registry source
+type Config struct {
+	Ecosystem string `yaml:"ecosystem" mapstructure:"ecosystem"`
+	// Package is the name of the package as known by the registry:
+	// the module path for Go modules and group:artifact for Maven Central
+	Package string `yaml:"package" mapstructure:"package"`
+	// IgnorePrereleases skips versions such as 1.0.0-rc.1, 2.0b1 or 1.0-SNAPSHOT
+	IgnorePrereleases bool `yaml:"ignore_prereleases" mapstructure:"ignore_prereleases"`
+}
+
+// Validate validates the package registry source configuration
+func (c *Config) Validate() error {
+	if !slices.Contains(validEcosystems, c.Ecosystem) {
+		return fmt.Errorf("invalid ecosystem: %s", c.Ecosystem)
+	}
+	if c.Package == "" {
+		return fmt.Errorf("package is required")
+	}
+	if c.Ecosystem == EcosystemMavenCentral {
+		group, artifact, ok := strings.Cut(c.Package, ":")
+		if !ok || group == "" || artifact == "" {
+			return fmt.Errorf("maven-central packages have to be in the group:artifact format: %s", c.Package)
+		}
+	}
+	return nil
+}
+
+func (c *Config) IsCrawler() {}
+
+func (c *Config) Build(name string) (model.Source, error) {
+	return NewAdapter(c, name)
+}
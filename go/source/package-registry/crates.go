@@ -0,0 +1,35 @@
+package package_registry
+
+import (
+	"context"
+	"net/url"
+	"time"
+)
+
+var cratesURL = "https://crates.io"
+
+func (a *Adapter) fetchCrates(ctx context.Context) ([]Version, error) {
+	var res struct {
+		Versions []struct {
+			Num       string    `json:"num"`
+			CreatedAt time.Time `json:"created_at"`
+			Yanked    bool      `json:"yanked"`
+		} `json:"versions"`
+	}
+
+	if err := a.get(ctx, cratesURL+"/api/v1/crates/"+url.PathEscape(a.config.Package)+"/versions?per_page=100", &res); err != nil {
+		return nil, err
+	}
+
+	var versions []Version
+	for _, version := range res.Versions {
+		versions = append(versions, Version{
+			Version:     version.Num,
+			PublishedAt: version.CreatedAt,
+			Yanked:      version.Yanked,
+			URL:         "https://crates.io/crates/" + a.config.Package + "/" + version.Num,
+		})
+	}
+
+	return versions, nil
+}
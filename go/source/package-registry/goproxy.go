@@ -0,0 +1,127 @@
+package package_registry
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+)
+
+var goProxyURL = "https://proxy.golang.org"
+
+// goProxyMaxVersions caps the .info requests, the proxy needing one request per version
+const goProxyMaxVersions = 20
+
+type goProxyInfo struct {
+	Version string    `json:"Version"`
+	Time    time.Time `json:"Time"`
+}
+
+func (a *Adapter) fetchGoProxy(ctx context.Context) ([]Version, error) {
+	modulePath, err := module.EscapePath(a.config.Package)
+	if err != nil {
+		return nil, fmt.Errorf("invalid module path: %w", err)
+	}
+	baseURL := goProxyURL + "/" + modulePath
+
+	resp, err := a.client.R().
+		SetContext(ctx).
+		Get(baseURL + "/@v/list")
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() != 200 {
+		return nil, fmt.Errorf("status code: %d, body: %s", resp.StatusCode(), resp.String())
+	}
+
+	var list []string
+	for _, line := range strings.Fields(resp.String()) {
+		if !semver.IsValid(line) {
+			continue
+		}
+		// skipped here as well, to save their .info requests
+		if a.config.IgnorePrereleases && semver.Prerelease(line) != "" {
+			continue
+		}
+		list = append(list, line)
+	}
+
+	// newest first
+	slices.SortFunc(list, func(a, b string) int {
+		return semver.Compare(b, a)
+	})
+	if len(list) > goProxyMaxVersions {
+		list = list[:goProxyMaxVersions]
+	}
+
+	// the go.mod of the latest version tells whether the module is deprecated and which versions are retracted
+	var latest goProxyInfo
+	if err := a.get(ctx, baseURL+"/@latest", &latest); err != nil {
+		return nil, err
+	}
+	deprecated, retracted, err := a.fetchGoModDirectives(ctx, baseURL, latest.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	// modules without any tag only have pseudo-versions
+	if len(list) == 0 {
+		list = []string{latest.Version}
+	}
+
+	var versions []Version
+	for _, version := range list {
+		info := latest
+		if version != latest.Version {
+			if err := a.get(ctx, baseURL+"/@v/"+version+".info", &info); err != nil {
+				return nil, err
+			}
+		}
+
+		versions = append(versions, Version{
+			Version:     version,
+			PublishedAt: info.Time,
+			Yanked:      retracted(version),
+			Deprecated:  deprecated,
+			URL:         "https://pkg.go.dev/" + a.config.Package + "@" + version,
+		})
+	}
+
+	return versions, nil
+}
+
+// fetchGoModDirectives reads the deprecation comment and the retract directives of the go.mod of version
+func (a *Adapter) fetchGoModDirectives(ctx context.Context, baseURL, version string) (bool, func(string) bool, error) {
+	resp, err := a.client.R().
+		SetContext(ctx).
+		Get(baseURL + "/@v/" + version + ".mod")
+	if err != nil {
+		return false, nil, err
+	}
+	if resp.StatusCode() != 200 {
+		return false, nil, fmt.Errorf("status code: %d, body: %s", resp.StatusCode(), resp.String())
+	}
+
+	file, err := modfile.ParseLax("go.mod", resp.Bytes(), nil)
+	if err != nil {
+		return false, nil, fmt.Errorf("failed to parse go.mod: %w", err)
+	}
+
+	deprecated := file.Module != nil && file.Module.Deprecated != ""
+
+	retracted := func(version string) bool {
+		for _, r := range file.Retract {
+			if semver.Compare(r.Low, version) <= 0 && semver.Compare(version, r.High) <= 0 {
+				return true
+			}
+		}
+		return false
+	}
+
+	return deprecated, retracted, nil
+}
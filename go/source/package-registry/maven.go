@@ -0,0 +1,45 @@
+package package_registry
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+var mavenCentralURL = "https://search.maven.org"
+
+func (a *Adapter) fetchMavenCentral(ctx context.Context) ([]Version, error) {
+	group, artifact, _ := strings.Cut(a.config.Package, ":")
+
+	params := url.Values{}
+	params.Set("q", fmt.Sprintf("g:%q AND a:%q", group, artifact))
+	params.Set("core", "gav")
+	params.Set("rows", "50")
+	params.Set("wt", "json")
+
+	var res struct {
+		Response struct {
+			Docs []struct {
+				Version   string `json:"v"`
+				Timestamp int64  `json:"timestamp"` // in milliseconds
+			} `json:"docs"`
+		} `json:"response"`
+	}
+
+	if err := a.get(ctx, mavenCentralURL+"/solrsearch/select?"+params.Encode(), &res); err != nil {
+		return nil, err
+	}
+
+	var versions []Version
+	for _, doc := range res.Response.Docs {
+		versions = append(versions, Version{
+			Version:     doc.Version,
+			PublishedAt: time.UnixMilli(doc.Timestamp),
+			URL:         fmt.Sprintf("https://central.sonatype.com/artifact/%s/%s/%s", group, artifact, doc.Version),
+		})
+	}
+
+	return versions, nil
+}
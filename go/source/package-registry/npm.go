@@ -0,0 +1,47 @@
+package package_registry
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"time"
+)
+
+var npmRegistryURL = "https://registry.npmjs.org"
+
+func (a *Adapter) fetchNPM(ctx context.Context) ([]Version, error) {
+	var res struct {
+		Versions map[string]struct {
+			Deprecated interface{} `json:"deprecated"`
+		} `json:"versions"`
+		Time map[string]time.Time `json:"time"`
+	}
+
+	// scoped packages keep their "@" but have their "/" escaped
+	name := strings.Replace(url.PathEscape(a.config.Package), "%40", "@", 1)
+
+	if err := a.get(ctx, npmRegistryURL+"/"+name, &res); err != nil {
+		return nil, err
+	}
+
+	var versions []Version
+	for version, details := range res.Versions {
+		// deprecated is the deprecation message, or false
+		deprecated := false
+		switch v := details.Deprecated.(type) {
+		case string:
+			deprecated = v != ""
+		case bool:
+			deprecated = v
+		}
+
+		versions = append(versions, Version{
+			Version:     version,
+			PublishedAt: res.Time[version],
+			Deprecated:  deprecated,
+			URL:         "https://www.npmjs.com/package/" + a.config.Package + "/v/" + version,
+		})
+	}
+
+	return versions, nil
+}
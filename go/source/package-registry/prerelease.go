@@ -0,0 +1,37 @@
+package package_registry
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+var (
+	// PEP 440 pre-releases and development releases, e.g. 2.0a1, 2.0rc1, 2.0.dev3
+	pep440PrereleaseRegex = regexp.MustCompile(`(?i)\d[._-]?(a|b|c|rc|alpha|beta|pre|preview|dev)[._-]?\d*`)
+	// Maven qualifiers, e.g. 1.0-SNAPSHOT, 2.0.0-M1, 3.0.0-RC2, 1.0-beta-1
+	mavenPrereleaseRegex = regexp.MustCompile(`(?i)[.-](alpha|beta|rc|cr|m\d+|milestone|snapshot|preview|ea)([.-]?\d+)*$`)
+)
+
+// isPrerelease reports whether version is a pre-release according to the versioning scheme of the ecosystem
+func isPrerelease(ecosystem, version string) bool {
+	switch ecosystem {
+	case EcosystemPyPI:
+		return pep440PrereleaseRegex.MatchString(version)
+	case EcosystemMavenCentral:
+		return mavenPrereleaseRegex.MatchString(version)
+	case EcosystemRubyGems:
+		// RubyGems treats any version containing a letter as a pre-release
+		return strings.ContainsFunc(version, func(r rune) bool {
+			return ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z')
+		})
+	default:
+		// npm, crates.io and Go modules follow semantic versioning
+		v, err := semver.NewVersion(version)
+		if err != nil {
+			return false
+		}
+		return v.Prerelease() != ""
+	}
+}
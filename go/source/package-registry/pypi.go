@@ -0,0 +1,49 @@
+package package_registry
+
+import (
+	"context"
+	"net/url"
+	"time"
+)
+
+var pypiURL = "https://pypi.org"
+
+func (a *Adapter) fetchPyPI(ctx context.Context) ([]Version, error) {
+	var res struct {
+		Releases map[string][]struct {
+			UploadTime time.Time `json:"upload_time_iso_8601"`
+			Yanked     bool      `json:"yanked"`
+		} `json:"releases"`
+	}
+
+	if err := a.get(ctx, pypiURL+"/pypi/"+url.PathEscape(a.config.Package)+"/json", &res); err != nil {
+		return nil, err
+	}
+
+	var versions []Version
+	for version, files := range res.Releases {
+		// versions without any file were never actually published
+		if len(files) == 0 {
+			continue
+		}
+
+		// a version is yanked as a whole, but the flag is reported per file
+		publishedAt := files[0].UploadTime
+		yanked := true
+		for _, file := range files {
+			if file.UploadTime.Before(publishedAt) {
+				publishedAt = file.UploadTime
+			}
+			yanked = yanked && file.Yanked
+		}
+
+		versions = append(versions, Version{
+			Version:     version,
+			PublishedAt: publishedAt,
+			Yanked:      yanked,
+			URL:         "https://pypi.org/project/" + a.config.Package + "/" + version + "/",
+		})
+	}
+
+	return versions, nil
+}
@@ -0,0 +1,32 @@
+package package_registry
+
+import (
+	"context"
+	"net/url"
+	"time"
+)
+
+var rubyGemsURL = "https://rubygems.org"
+
+func (a *Adapter) fetchRubyGems(ctx context.Context) ([]Version, error) {
+	// yanked versions are not listed by the API
+	var res []struct {
+		Number    string    `json:"number"`
+		CreatedAt time.Time `json:"created_at"`
+	}
+
+	if err := a.get(ctx, rubyGemsURL+"/api/v1/versions/"+url.PathEscape(a.config.Package)+".json", &res); err != nil {
+		return nil, err
+	}
+
+	var versions []Version
+	for _, version := range res {
+		versions = append(versions, Version{
+			Version:     version.Number,
+			PublishedAt: version.CreatedAt,
+			URL:         "https://rubygems.org/gems/" + a.config.Package + "/versions/" + version.Number,
+		})
+	}
+
+	return versions, nil
+}
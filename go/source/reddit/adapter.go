@@ -2,9 +2,12 @@ package reddit
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/sirupsen/logrus"
 	"resty.dev/v3"
 
 	"github.com/ryansiau/KeepUpdated/go/common"
@@ -15,6 +18,7 @@ import (
 type Adapter struct {
 	client *resty.Client
 	config *Config
+	target Target
 	name   string
 }
 
@@ -24,8 +28,10 @@ func NewAdapter(config *Config, name string) (*Adapter, error) {
 		return nil, err
 	}
 
+	target := newTarget(config)
+
 	if name == "" {
-		name = "Reddit: r/" + config.Subreddit
+		name = "Reddit: " + target.String()
 	}
 
 	client := resty.New().
@@ -34,6 +40,7 @@ func NewAdapter(config *Config, name string) (*Adapter, error) {
 
 	return &Adapter{
 		config: config,
+		target: target,
 		name:   name,
 		client: client,
 	}, nil
@@ -49,9 +56,27 @@ func (a *Adapter) Type() string {
 	return "reddit"
 }
 
-// Fetch retrieves new content from Reddit
+// Fetch retrieves new content from Reddit, nothing while the rate limit is exhausted
 func (a *Adapter) Fetch(ctx context.Context) ([]model.Content, error) {
-	feed, err := a.FetchRSS(ctx, a.config.Subreddit)
+	var contents []model.Content
+	var err error
+	if a.config.mode() == ModeJSON {
+		contents, err = a.fetchListing(ctx)
+	} else {
+		contents, err = a.fetchFeed(ctx)
+	}
+
+	if errors.Is(err, errRateLimited) {
+		resetAt, _ := a.rateLimitedUntil()
+		logrus.WithField("source", a.name).Warnf("Reddit rate limit exhausted, skipping until %s", resetAt.Format(time.RFC3339))
+		return nil, nil
+	}
+	return contents, err
+}
+
+// fetchFeed retrieves the posts from the Atom feed
+func (a *Adapter) fetchFeed(ctx context.Context) ([]model.Content, error) {
+	feed, err := a.FetchRSS(ctx, a.target)
 	if err != nil {
 		return nil, err
 	}
@@ -60,7 +85,7 @@ func (a *Adapter) Fetch(ctx context.Context) ([]model.Content, error) {
 	for _, post := range feed.Entry {
 		// Convert Reddit post to generic Content
 		content := model.Content{
-			ID:          post.ID,
+			ID:          a.contentID(post.ID),
 			SourceID:    a.SourceID(),
 			Title:       post.Title,
 			URL:         post.Link.Href,
@@ -78,8 +103,71 @@ func (a *Adapter) Fetch(ctx context.Context) ([]model.Content, error) {
 	return contents, nil
 }
 
+// fetchListing retrieves the posts from the JSON listing
+func (a *Adapter) fetchListing(ctx context.Context) ([]model.Content, error) {
+	listing, err := a.FetchListing(ctx, a.target, a.config.Sort, a.config.Time)
+	if err != nil {
+		return nil, err
+	}
+
+	var contents []model.Content
+	for _, child := range listing.Data.Children {
+		post := child.Data
+
+		// the fullname (t3_xxx) is also the id of the Atom feed entries,
+		// so switching between modes doesn't notify the same posts again
+		content := model.Content{
+			ID:          a.contentID(post.Name),
+			SourceID:    a.SourceID(),
+			Title:       post.Title,
+			Description: post.SelfText,
+			URL:         "https://www.reddit.com" + post.Permalink,
+			Author:      "/u/" + post.Author,
+			Platform:    "Reddit",
+			PublishedAt: time.Unix(int64(post.CreatedUTC), 0),
+			UpdatedAt:   time.Now(),
+			Metadata: map[string]interface{}{
+				"subreddit":    post.Subreddit,
+				"score":        post.Score,
+				"num_comments": post.NumComments,
+				"upvote_ratio": post.UpvoteRatio,
+				"over_18":      post.Over18,
+				"stickied":     post.Stickied,
+				"is_self":      post.IsSelf,
+				"domain":       post.Domain,
+				"flair":        post.LinkFlairText,
+				"link":         post.URL,
+			},
+		}
+		contents = append(contents, content)
+	}
+
+	return contents, nil
+}
+
+// SourceID returns the identifier of the source.
+// subreddits and multireddits keep the original "Reddit:<subreddit>" format.
 func (a *Adapter) SourceID() string {
-	return fmt.Sprintf("Reddit:%s", a.config.Subreddit)
+	subreddits := strings.Join(a.target.Subreddits, "+")
+
+	switch {
+	case a.target.User != "":
+		return fmt.Sprintf("Reddit:user:%s", a.target.User)
+	case a.target.Query != "":
+		return fmt.Sprintf("Reddit:search:%s:%s", subreddits, a.target.Query)
+	default:
+		return fmt.Sprintf("Reddit:%s", subreddits)
+	}
+}
+
+// contentID namespaces the id of the post by the source, as a post can be listed by several sources,
+// e.g. a subreddit and a search. single subreddits keep the bare ids they've always stored, no other
+// source using bare ids can list the same post.
+func (a *Adapter) contentID(postID string) string {
+	if a.target.User == "" && a.target.Query == "" && len(a.target.Subreddits) == 1 {
+		return postID
+	}
+	return a.SourceID() + ":" + postID
 }
 
 // parseTime converts a string time to a time.Time
@@ -0,0 +1,219 @@
+package reddit
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const listing = `{"kind": "Listing", "data": {"children": [
+	{"kind": "t3", "data": {"name": "t3_abc", "title": "Hello", "author": "gopher", "subreddit": "golang",
+		"permalink": "/r/golang/comments/abc/hello/", "created_utc": 1760000000}}
+]}}`
+
+// standIn serves the token endpoint and the listings, the fields set how it answers
+type standIn struct {
+	// expiresIn is the lifetime of the issued tokens in seconds
+	expiresIn int
+	// rejected tokens are answered with 401
+	rejected map[string]bool
+	// listingHeader is added to the listing responses, along with listingStatus when it's set
+	listingHeader http.Header
+	listingStatus int
+
+	tokenRequests   int
+	listingRequests int
+	// authorization is the Authorization header of the last listing request
+	authorization string
+}
+
+func newStandIn(t *testing.T) *standIn {
+	t.Helper()
+
+	s := &standIn{expiresIn: 3600, rejected: map[string]bool{}, listingHeader: http.Header{}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/v1/access_token":
+			if id, secret, ok := r.BasicAuth(); !ok || id == "" || secret != "secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			if err := r.ParseForm(); err != nil || r.PostForm.Get("grant_type") != "client_credentials" {
+				t.Errorf("unexpected token request: %v", r.PostForm)
+			}
+			s.tokenRequests++
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprintf(w, `{"access_token": "token-%d", "token_type": "bearer", "expires_in": %d}`, s.tokenRequests, s.expiresIn)
+		case strings.HasSuffix(r.URL.Path, ".json"):
+			s.listingRequests++
+			s.authorization = r.Header.Get("Authorization")
+			if s.rejected[strings.TrimPrefix(s.authorization, "Bearer ")] {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			for key, values := range s.listingHeader {
+				w.Header()[key] = values
+			}
+			w.Header().Set("Content-Type", "application/json")
+			if s.listingStatus != 0 {
+				w.WriteHeader(s.listingStatus)
+				return
+			}
+			_, _ = w.Write([]byte(listing))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	previousTokenURL, previousOAuthBaseURL, previousPublicBaseURL := tokenURL, oauthBaseURL, publicBaseURL
+	tokenURL = server.URL + "/api/v1/access_token"
+	oauthBaseURL = server.URL
+	publicBaseURL = server.URL
+	t.Cleanup(func() {
+		tokenURL, oauthBaseURL, publicBaseURL = previousTokenURL, previousOAuthBaseURL, previousPublicBaseURL
+	})
+
+	// the caches outlive the adapters
+	tokens = map[string]token{}
+	rateLimits = map[string]rateLimit{}
+
+	return s
+}
+
+func newTestAdapter(t *testing.T) *Adapter {
+	t.Helper()
+
+	adapter, err := NewAdapter(&Config{Subreddit: "golang", ClientID: "client", ClientSecret: "secret"}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return adapter
+}
+
+func TestAccessToken(t *testing.T) {
+	s := newStandIn(t)
+	adapter := newTestAdapter(t)
+
+	for range 2 {
+		contents, err := adapter.Fetch(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(contents) != 1 || contents[0].ID != "t3_abc" {
+			t.Fatalf("unexpected contents: %v", contents)
+		}
+	}
+	if s.tokenRequests != 1 {
+		t.Errorf("requested %d tokens, want the first one to be cached", s.tokenRequests)
+	}
+	if s.authorization != "Bearer token-1" {
+		t.Errorf("listing requested with %q", s.authorization)
+	}
+
+	// tokens about to expire are replaced
+	s.expiresIn = 30
+	tokens = map[string]token{}
+	for range 2 {
+		if _, err := adapter.Fetch(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if s.tokenRequests != 3 {
+		t.Errorf("requested %d tokens, want a new one for each request once they expire within the margin", s.tokenRequests)
+	}
+}
+
+func TestRejectedToken(t *testing.T) {
+	s := newStandIn(t)
+	s.rejected["token-1"] = true
+	adapter := newTestAdapter(t)
+
+	contents, err := adapter.Fetch(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(contents) != 1 {
+		t.Fatalf("got %d contents, want the listing fetched with a refreshed token", len(contents))
+	}
+	if s.tokenRequests != 2 || s.authorization != "Bearer token-2" {
+		t.Errorf("requested %d tokens, listing requested with %q", s.tokenRequests, s.authorization)
+	}
+
+	// a refreshed token being rejected too isn't retried forever
+	s.rejected["token-2"] = true
+	s.rejected["token-3"] = true
+	if _, err := adapter.Fetch(context.Background()); err == nil {
+		t.Error("expected an error once the refreshed token is rejected")
+	}
+}
+
+func TestRateLimitHeaders(t *testing.T) {
+	s := newStandIn(t)
+	s.listingHeader.Set("X-Ratelimit-Remaining", "0.0")
+	s.listingHeader.Set("X-Ratelimit-Reset", "300")
+	adapter := newTestAdapter(t)
+
+	contents, err := adapter.Fetch(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(contents) != 1 {
+		t.Fatalf("got %d contents, want the listing of the last request of the window", len(contents))
+	}
+
+	resetAt, limited := adapter.rateLimitedUntil()
+	if !limited || time.Until(resetAt) < 299*time.Second {
+		t.Fatalf("rate limit exhausted: %v until %s, want 5 minutes", limited, resetAt)
+	}
+
+	// the exhausted window is skipped without a request
+	contents, err = adapter.Fetch(context.Background())
+	if err != nil || contents != nil {
+		t.Errorf("got %v, %v while the rate limit is exhausted, want nothing", contents, err)
+	}
+	if s.listingRequests != 1 {
+		t.Errorf("sent %d listing requests, want 1", s.listingRequests)
+	}
+
+	// the unauthenticated client has its own limits
+	public, err := NewAdapter(&Config{Subreddit: "golang", Mode: ModeJSON}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, limited := public.rateLimitedUntil(); limited {
+		t.Error("the rate limit of the OAuth client applied to the unauthenticated one")
+	}
+}
+
+func TestTooManyRequests(t *testing.T) {
+	s := newStandIn(t)
+	s.listingStatus = http.StatusTooManyRequests
+	s.listingHeader.Set("Retry-After", "120")
+	adapter := newTestAdapter(t)
+
+	start := time.Now()
+	contents, err := adapter.Fetch(context.Background())
+	if err != nil || contents != nil {
+		t.Fatalf("got %v, %v after a 429, want nothing", contents, err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("fetching took %s, it shouldn't wait for the window to reset", elapsed)
+	}
+
+	resetAt, limited := adapter.rateLimitedUntil()
+	if !limited || time.Until(resetAt) < 119*time.Second {
+		t.Fatalf("rate limit exhausted: %v until %s, want the Retry-After delay", limited, resetAt)
+	}
+
+	if _, err := adapter.Fetch(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if s.listingRequests != 1 {
+		t.Errorf("sent %d listing requests, want the one answered with 429", s.listingRequests)
+	}
+}
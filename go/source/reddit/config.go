@@ -2,23 +2,93 @@ package reddit
 
 import (
 	"fmt"
+	"slices"
 
 	"github.com/ryansiau/KeepUpdated/go/model"
 )
 
+// Fetch modes
+const (
+	ModeRSS  = "rss"
+	ModeJSON = "json"
+)
+
+var (
+	validModes = []string{ModeRSS, ModeJSON}
+	validSorts = []string{"new", "hot", "top", "rising"}
+	validTimes = []string{"hour", "day", "week", "month", "year", "all"}
+)
+
 // Config represents the configuration for a Reddit source
 type Config struct {
+	// Subreddit is a single subreddit, or a multireddit written as "a+b+c"
 	Subreddit string `yaml:"subreddit" mapstructure:"subreddit"`
+	// User follows the submissions of a user instead of a subreddit
+	User string `yaml:"user" mapstructure:"user"`
+	// Query searches the subreddit instead of listing it
+	Query string `yaml:"query" mapstructure:"query"`
+
+	// Mode is either "rss" or "json". The JSON listing exposes score,
+	// comment count, flair, etc. in the content metadata.
+	// Defaults to "json" when OAuth credentials are set, "rss" otherwise.
+	Mode string `yaml:"mode" mapstructure:"mode"`
+	// Sort and Time are only used in json mode. Time is the `t` window of the "top" sort.
+	Sort string `yaml:"sort" mapstructure:"sort"`
+	Time string `yaml:"t" mapstructure:"t"`
+
+	// ClientID and ClientSecret of a Reddit app, enabling application-only OAuth
+	// which is far less throttled than anonymous requests. Only used in json mode.
+	ClientID     string `yaml:"client_id" mapstructure:"client_id"`
+	ClientSecret string `yaml:"client_secret" mapstructure:"client_secret"`
 }
 
 // Validate validates the Reddit source configuration
 func (r *Config) Validate() error {
-	if r.Subreddit == "" {
-		return fmt.Errorf("subreddit is required")
+	if r.Subreddit == "" && r.User == "" {
+		return fmt.Errorf("subreddit or user is required")
+	}
+	if r.Subreddit != "" && r.User != "" {
+		return fmt.Errorf("only one of subreddit or user can be set")
+	}
+	if r.Query != "" && r.Subreddit == "" {
+		return fmt.Errorf("query requires a subreddit")
+	}
+	if err := newTarget(r).validate(); err != nil {
+		return err
+	}
+	if r.Mode != "" && !slices.Contains(validModes, r.Mode) {
+		return fmt.Errorf("invalid mode: %s", r.Mode)
+	}
+	if r.Sort != "" && !slices.Contains(validSorts, r.Sort) {
+		return fmt.Errorf("invalid sort: %s", r.Sort)
+	}
+	if r.Time != "" && !slices.Contains(validTimes, r.Time) {
+		return fmt.Errorf("invalid t: %s", r.Time)
+	}
+	if (r.ClientID == "") != (r.ClientSecret == "") {
+		return fmt.Errorf("client_id and client_secret have to be set together")
+	}
+	if (r.Sort != "" || r.Time != "") && r.mode() != ModeJSON {
+		return fmt.Errorf("sort and t are only supported in json mode")
 	}
 	return nil
 }
 
+func (r *Config) hasCredentials() bool {
+	return r.ClientID != "" && r.ClientSecret != ""
+}
+
+// mode returns the configured mode, falling back to json when authenticated
+func (r *Config) mode() string {
+	if r.Mode != "" {
+		return r.Mode
+	}
+	if r.hasCredentials() {
+		return ModeJSON
+	}
+	return ModeRSS
+}
+
 func (r *Config) IsCrawler() {}
 
 func (c *Config) Build(name string) (model.Source, error) {
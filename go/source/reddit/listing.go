@@ -0,0 +1,56 @@
+package reddit
+
+import (
+	"context"
+	"fmt"
+)
+
+func (a *Adapter) FetchListing(ctx context.Context, target Target, sort, t string) (*Listing, error) {
+	res := Listing{}
+
+	resp, err := a.get(ctx, target.JSONURL(a.baseURL(), sort, t), &res)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode() != 200 {
+		return nil, fmt.Errorf("status code: %d, body: %s", resp.StatusCode(), resp.String())
+	}
+
+	return &res, nil
+}
+
+type Listing struct {
+	Kind string `json:"kind"`
+	Data struct {
+		Children []struct {
+			Kind string `json:"kind"`
+			Data Post   `json:"data"`
+		} `json:"children"`
+		After   string `json:"after"`
+		Before  string `json:"before"`
+		Dist    int    `json:"dist"`
+		Modhash string `json:"modhash"`
+	} `json:"data"`
+}
+
+type Post struct {
+	Title         string  `json:"title"`
+	Subreddit     string  `json:"subreddit"`
+	Author        string  `json:"author"`
+	URL           string  `json:"url"`
+	Permalink     string  `json:"permalink"`
+	Domain        string  `json:"domain"`
+	Score         int     `json:"score"`
+	NumComments   int     `json:"num_comments"`
+	CreatedUTC    float64 `json:"created_utc"`
+	SelfText      string  `json:"selftext"`
+	Thumbnail     string  `json:"thumbnail"`
+	ID            string  `json:"id"`
+	Name          string  `json:"name"`
+	UpvoteRatio   float64 `json:"upvote_ratio"`
+	Stickied      bool    `json:"stickied"`
+	Over18        bool    `json:"over_18"`
+	IsSelf        bool    `json:"is_self"`
+	LinkFlairText string  `json:"link_flair_text"`
+}
@@ -0,0 +1,95 @@
+package reddit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// tokenURL issues application-only tokens, oauthBaseURL serves the listings to authenticated clients.
+// they are variables so a local stand-in server can take their place.
+var (
+	tokenURL     = "https://www.reddit.com/api/v1/access_token"
+	oauthBaseURL = "https://oauth.reddit.com"
+)
+
+type token struct {
+	accessToken string
+	expiresAt   time.Time
+}
+
+// tokens caches the access tokens by client ID.
+// adapters are rebuilt on every execution, so the cache can't live in the Adapter itself.
+var (
+	tokensMu sync.Mutex
+	tokens   = map[string]token{}
+)
+
+// tokenExpiryMargin refreshes tokens a little before they actually expire
+const tokenExpiryMargin = time.Minute
+
+// useOAuth reports whether the requests go through oauth.reddit.com.
+// the Atom feeds are only served to unauthenticated clients, so OAuth is limited to the json mode.
+func (a *Adapter) useOAuth() bool {
+	return a.config.hasCredentials() && a.config.mode() == ModeJSON
+}
+
+// baseURL returns the host serving the listings
+func (a *Adapter) baseURL() string {
+	if a.useOAuth() {
+		return oauthBaseURL
+	}
+	return publicBaseURL
+}
+
+// accessToken returns a cached token, requesting a new one through the client credentials grant if needed
+func (a *Adapter) accessToken(ctx context.Context) (string, error) {
+	tokensMu.Lock()
+	defer tokensMu.Unlock()
+
+	if t, ok := tokens[a.config.ClientID]; ok && time.Now().Before(t.expiresAt.Add(-tokenExpiryMargin)) {
+		return t.accessToken, nil
+	}
+
+	var res struct {
+		AccessToken string `json:"access_token"`
+		TokenType   string `json:"token_type"`
+		ExpiresIn   int    `json:"expires_in"`
+		Error       string `json:"error"`
+	}
+
+	resp, err := a.client.R().
+		SetContext(ctx).
+		SetBasicAuth(a.config.ClientID, a.config.ClientSecret).
+		SetFormData(map[string]string{
+			"grant_type": "client_credentials",
+		}).
+		SetResult(&res).
+		Post(tokenURL)
+	if err != nil {
+		return "", err
+	}
+
+	if resp.StatusCode() != 200 {
+		return "", fmt.Errorf("failed to get access token, status code: %d, body: %s", resp.StatusCode(), resp.String())
+	}
+	if res.Error != "" || res.AccessToken == "" {
+		return "", fmt.Errorf("failed to get access token: %s", res.Error)
+	}
+
+	tokens[a.config.ClientID] = token{
+		accessToken: res.AccessToken,
+		expiresAt:   time.Now().Add(time.Duration(res.ExpiresIn) * time.Second),
+	}
+
+	return res.AccessToken, nil
+}
+
+// invalidateToken drops the cached token, e.g. after it has been rejected
+func (a *Adapter) invalidateToken() {
+	tokensMu.Lock()
+	defer tokensMu.Unlock()
+
+	delete(tokens, a.config.ClientID)
+}
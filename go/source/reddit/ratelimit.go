@@ -0,0 +1,134 @@
+package reddit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"resty.dev/v3"
+)
+
+// errRateLimited is returned by get while the rate limit window is exhausted
+var errRateLimited = errors.New("reddit rate limit exhausted")
+
+type rateLimit struct {
+	remaining float64
+	resetAt   time.Time
+}
+
+// rateLimits tracks the X-Ratelimit-* headers by client ID, "" being the unauthenticated client
+var (
+	rateLimitsMu sync.Mutex
+	rateLimits   = map[string]rateLimit{}
+)
+
+// rateLimitKey returns the client ID when authenticated, the unauthenticated limits being tracked under ""
+func (a *Adapter) rateLimitKey() string {
+	if a.useOAuth() {
+		return a.config.ClientID
+	}
+	return ""
+}
+
+// rateLimitedUntil returns the reset of the rate limit window if the previous response exhausted it
+func (a *Adapter) rateLimitedUntil() (time.Time, bool) {
+	rateLimitsMu.Lock()
+	limit, ok := rateLimits[a.rateLimitKey()]
+	rateLimitsMu.Unlock()
+
+	if !ok || limit.remaining >= 1 || !time.Now().Before(limit.resetAt) {
+		return time.Time{}, false
+	}
+	return limit.resetAt, true
+}
+
+// updateRateLimit records the rate limit headers of a response
+func (a *Adapter) updateRateLimit(header http.Header) {
+	remaining, err := strconv.ParseFloat(header.Get("X-Ratelimit-Remaining"), 64)
+	if err != nil {
+		return
+	}
+	reset, err := strconv.Atoi(header.Get("X-Ratelimit-Reset"))
+	if err != nil {
+		return
+	}
+
+	rateLimitsMu.Lock()
+	defer rateLimitsMu.Unlock()
+
+	rateLimits[a.rateLimitKey()] = rateLimit{
+		remaining: remaining,
+		resetAt:   time.Now().Add(time.Duration(reset) * time.Second),
+	}
+}
+
+// get requests a listing, taking care of authentication and rate limits.
+// a rejected token is refreshed once, and errRateLimited is returned without waiting for the window to reset,
+// as sources are executed one at a time.
+func (a *Adapter) get(ctx context.Context, url string, result interface{}) (*resty.Response, error) {
+	var resp *resty.Response
+
+	for attempt := 0; attempt < 2; attempt++ {
+		if _, limited := a.rateLimitedUntil(); limited {
+			return nil, errRateLimited
+		}
+
+		req := a.client.R().
+			SetContext(ctx).
+			SetResult(result)
+
+		if a.useOAuth() {
+			accessToken, err := a.accessToken(ctx)
+			if err != nil {
+				return nil, err
+			}
+			req.SetAuthToken(accessToken)
+		}
+
+		var err error
+		resp, err = req.Get(url)
+		if err != nil {
+			return nil, err
+		}
+
+		a.updateRateLimit(resp.Header())
+
+		switch resp.StatusCode() {
+		case http.StatusUnauthorized:
+			if !a.useOAuth() {
+				return resp, nil
+			}
+			a.invalidateToken()
+		case http.StatusTooManyRequests:
+			// make sure the next executions skip, even if the headers were missing
+			a.exhaustRateLimit(resp.Header())
+			return nil, errRateLimited
+		default:
+			return resp, nil
+		}
+	}
+
+	return resp, nil
+}
+
+// exhaustRateLimit marks the rate limit as exhausted after a 429
+func (a *Adapter) exhaustRateLimit(header http.Header) {
+	reset, err := strconv.Atoi(header.Get("Retry-After"))
+	if err != nil {
+		reset, err = strconv.Atoi(header.Get("X-Ratelimit-Reset"))
+	}
+	if err != nil {
+		reset = 60
+	}
+
+	rateLimitsMu.Lock()
+	defer rateLimitsMu.Unlock()
+
+	rateLimits[a.rateLimitKey()] = rateLimit{
+		remaining: 0,
+		resetAt:   time.Now().Add(time.Duration(reset) * time.Second),
+	}
+}
@@ -6,13 +6,13 @@ import (
 	"fmt"
 )
 
-func (a *Adapter) FetchRSS(ctx context.Context, subreddit string) (*Feed, error) {
+// publicBaseURL is the host serving the listings to unauthenticated clients
+var publicBaseURL = "https://www.reddit.com"
+
+func (a *Adapter) FetchRSS(ctx context.Context, target Target) (*Feed, error) {
 	res := Feed{}
 
-	resp, err := a.client.R().
-		SetContext(ctx).
-		SetResult(&res).
-		Get("https://www.reddit.com/r/" + subreddit + "/.rss")
+	resp, err := a.get(ctx, target.RSSURL(a.baseURL()), &res)
 	if err != nil {
 		return nil, err
 	}
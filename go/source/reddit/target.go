@@ -0,0 +1,132 @@
+package reddit
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var (
+	subredditRegex = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_]{1,20}$`)
+	usernameRegex  = regexp.MustCompile(`^[A-Za-z0-9_-]{3,20}$`)
+)
+
+// Target is the listing a source follows: a subreddit, a multireddit,
+// a user's submissions or a search restricted to a subreddit.
+type Target struct {
+	Subreddits []string
+	User       string
+	Query      string
+}
+
+// newTarget builds the target out of a validated configuration
+func newTarget(c *Config) Target {
+	t := Target{
+		User:  c.User,
+		Query: c.Query,
+	}
+	if c.Subreddit != "" {
+		t.Subreddits = strings.Split(c.Subreddit, "+")
+	}
+	return t
+}
+
+// validate checks the subreddit and user names, since they're used as part of the URL path
+func (t Target) validate() error {
+	for _, subreddit := range t.Subreddits {
+		if !subredditRegex.MatchString(subreddit) {
+			return fmt.Errorf("invalid subreddit: %q", subreddit)
+		}
+	}
+	if t.User != "" && !usernameRegex.MatchString(t.User) {
+		return fmt.Errorf("invalid user: %q", t.User)
+	}
+	return nil
+}
+
+// String returns the target the way it is written on Reddit, e.g. r/golang+rust or u/spez
+func (t Target) String() string {
+	switch {
+	case t.User != "":
+		return "u/" + t.User
+	case t.Query != "":
+		return fmt.Sprintf("r/%s search %q", strings.Join(t.Subreddits, "+"), t.Query)
+	default:
+		return "r/" + strings.Join(t.Subreddits, "+")
+	}
+}
+
+// path returns the escaped listing path, without extension
+func (t Target) path() string {
+	if t.User != "" {
+		return "/user/" + url.PathEscape(t.User) + "/submitted"
+	}
+
+	escaped := make([]string, len(t.Subreddits))
+	for i, subreddit := range t.Subreddits {
+		escaped[i] = url.PathEscape(subreddit)
+	}
+
+	path := "/r/" + strings.Join(escaped, "+")
+	if t.Query != "" {
+		path += "/search"
+	}
+	return path
+}
+
+// query returns the query parameters needed by the listing
+func (t Target) query() url.Values {
+	values := url.Values{}
+	if t.Query != "" {
+		values.Set("q", t.Query)
+		values.Set("restrict_sr", "1")
+		values.Set("sort", "new")
+	}
+	return values
+}
+
+// RSSURL returns the URL of the Atom feed of the listing
+func (t Target) RSSURL(baseURL string) string {
+	path := t.path()
+	if t.Query != "" {
+		// search.rss, as search/.rss ignores the query
+		path += ".rss"
+	} else {
+		path += "/.rss"
+	}
+	return buildURL(baseURL, path, t.query())
+}
+
+// JSONURL returns the URL of the JSON listing sorted by sort, with t as the time window of the "top" sort
+func (t Target) JSONURL(baseURL, sort, window string) string {
+	if sort == "" {
+		sort = "new"
+	}
+
+	values := t.query()
+	values.Set("limit", "25")
+	values.Set("raw_json", "1")
+	if window != "" {
+		values.Set("t", window)
+	}
+
+	path := t.path()
+	if t.User != "" || t.Query != "" {
+		// user and search listings take the sort as a parameter instead of a path segment
+		values.Set("sort", sort)
+		path += ".json"
+	} else {
+		path += "/" + sort + ".json"
+	}
+
+	return buildURL(baseURL, path, values)
+}
+
+func buildURL(baseURL, path string, values url.Values) string {
+	u := baseURL + path
+	if len(values) > 0 {
+		u += "?" + values.Encode()
+	}
+	return u
+}
@@ -1,8 +1,6 @@
-// Package twitter is supposed to be a package that handles crawling user's new posts from twitter Official API.
-// However, twitter's public API is very limited and does not seem to allow even 4 API calls/day.
-// This means the API key has to be of a paid plan to allow more calls, and I'm not fond of the idea
-// to get paid plan just to get updates from some handles.
-// Therefore, this package is held and might be canceled.
+// Package twitter handles crawling user's new posts from twitter Official API.
+// Note that twitter's free API tier is very limited and does not seem to allow even 4 API calls/day,
+// so the bearer token practically has to be of a paid plan. Keep the interval of these workflows long.
 package twitter
 
 import (
@@ -10,53 +8,66 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ryansiau/KeepUpdated/go/common"
+	"github.com/ryansiau/KeepUpdated/go/model"
 )
 
-// Content represents a tweet/post from Twitter
-type Content struct {
-	ID          string    `json:"id"`
-	Title       string    `json:"title"`
-	URL         string    `json:"url"`
-	Author      string    `json:"author"`
-	Text        string    `json:"text"`
-	PublishedAt time.Time `json:"published_at"`
-	MediaURLs   []string  `json:"media_urls,omitempty"`
-	Likes       int       `json:"likes,omitempty"`
-	Retweets    int       `json:"retweets,omitempty"`
-	Replies     int       `json:"replies,omitempty"`
-	QuoteTweets int       `json:"quote_tweets,omitempty"`
-}
+// apiBaseURL is the base of the Twitter API v2
+const apiBaseURL = "https://api.twitter.com/2"
 
-// Config holds Twitter API configuration for a specific user
-type Config struct {
-	BearerToken     string `json:"bearer_token"`
-	Username        string `json:"username"`         // The Twitter username to monitor
-	UserID          string `json:"user_id"`          // Optional: User ID (more reliable than username)
-	SinceID         string `json:"since_id"`         // Last fetched tweet ID
-	MaxResults      int    `json:"max_results"`      // Max tweets per request (1-100)
-	IncludeRetweets bool   `json:"include_retweets"` // Whether to include retweets
-	IncludeReplies  bool   `json:"include_replies"`  // Whether to include replies
-}
+// defaultMaxResults is used when max_results is not configured
+const defaultMaxResults = 10
+
+// Keys of the state stored between executions
+const (
+	stateSinceID        = "since_id"
+	stateUserID         = "user_id"
+	stateRateLimitReset = "rate_limit_reset"
+)
 
 // Twitter implements the Source interface for Twitter/X user posts
 type Twitter struct {
 	config Config
 	client *http.Client
 	name   string
+
+	username string
+	userID   string
+	sinceID  string
+
+	// rateLimitReset is set once the rate limit is exhausted, requests are skipped until then
+	rateLimitReset time.Time
+
+	// instance is the last Nitter or RSS-Bridge instance that responded
+	instance string
 }
 
+var _ model.StatefulSource = (*Twitter)(nil)
+
 // New creates a new Twitter source for a specific user
-func New(config Config) *Twitter {
-	name := fmt.Sprintf("Twitter Crawler - @%s", config.Username)
-	if config.Username == "" && config.UserID != "" {
-		name = fmt.Sprintf("Twitter Crawler - User %s", config.UserID)
+func New(config *Config, name string) *Twitter {
+	username := normalizeUsername(config.Username)
+
+	if name == "" {
+		name = fmt.Sprintf("Twitter: @%s", username)
+		if username == "" {
+			name = fmt.Sprintf("Twitter: User %s", config.UserID)
+		}
 	}
 
 	return &Twitter{
-		config: config,
-		client: &http.Client{Timeout: 30 * time.Second},
-		name:   name,
+		config:   *config,
+		client:   &http.Client{Timeout: 30 * time.Second},
+		name:     name,
+		username: username,
+		userID:   config.UserID,
 	}
 }
 
@@ -70,49 +81,133 @@ func (t *Twitter) Type() string {
 	return "Twitter"
 }
 
+// SourceID returns the identifier of the source. the feed modes have their own, as their state
+// and the ids of their tweets are independent of the API's.
+func (t *Twitter) SourceID() string {
+	if t.config.Mode == ModeNitter || t.config.Mode == ModeRSSBridge {
+		return fmt.Sprintf("Twitter:%s:%s", t.config.Mode, strings.ToLower(t.username))
+	}
+	if t.username != "" {
+		return fmt.Sprintf("Twitter:%s", strings.ToLower(t.username))
+	}
+	return fmt.Sprintf("Twitter:id:%s", t.config.UserID)
+}
+
+// LoadState restores the since_id, the resolved user ID, the rate limit reset time and the last working instance
+func (t *Twitter) LoadState(state map[string]string) {
+	t.sinceID = state[stateSinceID]
+
+	if t.userID == "" {
+		t.userID = state[stateUserID]
+	}
+
+	if reset, err := strconv.ParseInt(state[stateRateLimitReset], 10, 64); err == nil {
+		t.rateLimitReset = time.Unix(reset, 0)
+	}
+
+	t.instance = state[stateInstance]
+}
+
+// State returns the cursor to be stored for the next execution
+func (t *Twitter) State() map[string]string {
+	state := map[string]string{
+		stateSinceID: t.sinceID,
+		stateUserID:  t.userID,
+	}
+	if !t.rateLimitReset.IsZero() {
+		state[stateRateLimitReset] = strconv.FormatInt(t.rateLimitReset.Unix(), 10)
+	}
+	if t.instance != "" {
+		state[stateInstance] = t.instance
+	}
+	return state
+}
+
 // Fetch retrieves new posts from the user since the last check
-func (t *Twitter) Fetch(ctx context.Context) ([]Content, error) {
-	userID := t.config.UserID
+func (t *Twitter) Fetch(ctx context.Context) ([]model.Content, error) {
+	if t.config.Mode == ModeNitter || t.config.Mode == ModeRSSBridge {
+		return t.fetchFeed(ctx)
+	}
+
+	if time.Now().Before(t.rateLimitReset) {
+		logrus.WithField("source", t.name).Warnf("Twitter rate limit exhausted until %s, skipping", t.rateLimitReset.Format(time.RFC3339))
+		return nil, nil
+	}
 
 	// If we don't have UserID, resolve username to UserID first
-	if userID == "" && t.config.Username != "" {
-		resolvedUserID, err := t.resolveUsername(ctx, t.config.Username)
+	if t.userID == "" && t.username != "" {
+		resolvedUserID, err := t.resolveUsername(ctx, t.username)
+		if err == errRateLimited {
+			logrus.WithField("source", t.name).Warnf("Twitter rate limit exceeded, skipping until %s", t.rateLimitReset.Format(time.RFC3339))
+			return nil, nil
+		}
 		if err != nil {
-			return nil, fmt.Errorf("failed to resolve username %s: %w", t.config.Username, err)
+			return nil, fmt.Errorf("failed to resolve username %s: %w", t.username, err)
 		}
-		userID = resolvedUserID
-		t.config.UserID = userID // Cache for future use
+		t.userID = resolvedUserID // Stored in the state for future use
 	}
 
-	if userID == "" {
+	if t.userID == "" {
 		return nil, fmt.Errorf("no user ID or username provided")
 	}
 
-	return t.fetchUserTweets(ctx, userID)
+	return t.fetchUserTweets(ctx, t.userID)
 }
 
-// resolveUsername converts a username to a User ID
-func (t *Twitter) resolveUsername(ctx context.Context, username string) (string, error) {
-	url := fmt.Sprintf("https://api.twitter.com/2/users/by/username/%s", username)
+// errRateLimited is returned by do when the API answered with 429
+var errRateLimited = fmt.Errorf("twitter API rate limit exceeded")
 
+// do sends an authenticated GET request and decodes the JSON response into result
+func (t *Twitter) do(ctx context.Context, url string, result interface{}) error {
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
-		return "", err
+		return err
 	}
 
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", t.config.BearerToken))
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", common.HTTPClientUserAgent)
 
 	resp, err := t.client.Do(req)
 	if err != nil {
-		return "", err
+		return err
 	}
 	defer resp.Body.Close()
 
+	t.updateRateLimit(resp.Header)
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		if t.rateLimitReset.IsZero() || t.rateLimitReset.Before(time.Now()) {
+			// the reset header was missing, back off for the length of a rate limit window
+			t.rateLimitReset = time.Now().Add(15 * time.Minute)
+		}
+		return errRateLimited
+	}
+
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("twitter API returned status: %d for username resolution", resp.StatusCode)
+		return fmt.Errorf("twitter API returned status: %d", resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+// updateRateLimit reads the x-rate-limit-* headers, remembering the reset time once the limit is exhausted
+func (t *Twitter) updateRateLimit(header http.Header) {
+	remaining, err := strconv.Atoi(header.Get("x-rate-limit-remaining"))
+	if err != nil || remaining > 0 {
+		return
 	}
 
+	reset, err := strconv.ParseInt(header.Get("x-rate-limit-reset"), 10, 64)
+	if err != nil {
+		return
+	}
+
+	t.rateLimitReset = time.Unix(reset, 0)
+}
+
+// resolveUsername converts a username to a User ID
+func (t *Twitter) resolveUsername(ctx context.Context, username string) (string, error) {
 	var result struct {
 		Data struct {
 			ID       string `json:"id"`
@@ -121,20 +216,31 @@ func (t *Twitter) resolveUsername(ctx context.Context, username string) (string,
 		} `json:"data"`
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	err := t.do(ctx, fmt.Sprintf("%s/users/by/username/%s", apiBaseURL, url.PathEscape(username)), &result)
+	if err != nil {
 		return "", err
 	}
 
+	if result.Data.ID == "" {
+		return "", fmt.Errorf("user @%s not found", username)
+	}
+
 	return result.Data.ID, nil
 }
 
 // fetchUserTweets fetches tweets from a specific user by their ID
-func (t *Twitter) fetchUserTweets(ctx context.Context, userID string) ([]Content, error) {
+func (t *Twitter) fetchUserTweets(ctx context.Context, userID string) ([]model.Content, error) {
+	maxResults := t.config.MaxResults
+	if maxResults == 0 {
+		maxResults = defaultMaxResults
+	}
+
 	// Build query parameters
-	params := fmt.Sprintf("max_results=%d", t.config.MaxResults)
+	params := url.Values{}
+	params.Set("max_results", strconv.Itoa(maxResults))
 
-	if t.config.SinceID != "" {
-		params += fmt.Sprintf("&since_id=%s", t.config.SinceID)
+	if t.sinceID != "" {
+		params.Set("since_id", t.sinceID)
 	}
 
 	// Build exclusions
@@ -147,78 +253,94 @@ func (t *Twitter) fetchUserTweets(ctx context.Context, userID string) ([]Content
 	}
 
 	if len(exclusions) > 0 {
-		params += "&exclude="
-		for i, exclusion := range exclusions {
-			if i > 0 {
-				params += ","
-			}
-			params += exclusion
-		}
+		params.Set("exclude", strings.Join(exclusions, ","))
 	}
 
 	// Add tweet fields
-	params += "&tweet.fields=author_id,created_at,public_metrics,text,conversation_id"
+	params.Set("tweet.fields", "author_id,created_at,public_metrics,text,conversation_id,in_reply_to_user_id,referenced_tweets")
 
-	url := fmt.Sprintf("https://api.twitter.com/2/users/%s/tweets?%s", userID, params)
-
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return nil, err
+	var result twitterResponse
+	err := t.do(ctx, fmt.Sprintf("%s/users/%s/tweets?%s", apiBaseURL, url.PathEscape(userID), params.Encode()), &result)
+	if err == errRateLimited {
+		logrus.WithField("source", t.name).Warnf("Twitter rate limit exceeded, skipping until %s", t.rateLimitReset.Format(time.RFC3339))
+		return nil, nil
 	}
-
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", t.config.BearerToken))
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := t.client.Do(req)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("twitter API returned status: %d", resp.StatusCode)
-	}
-
-	var result twitterResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, err
+	if result.Meta.NewestID != "" {
+		t.sinceID = result.Meta.NewestID
 	}
 
 	return t.parseTweets(result.Data), nil
 }
 
 // parseTweets converts Twitter API response to Content objects
-func (t *Twitter) parseTweets(tweets []tweetData) []Content {
-	var content []Content
+func (t *Twitter) parseTweets(tweets []tweetData) []model.Content {
+	var contents []model.Content
+
+	author := "@" + t.username
+	if t.username == "" {
+		author = t.userID
+	}
 
 	for _, tweet := range tweets {
-		publishedAt, _ := time.Parse(time.RFC3339, tweet.CreatedAt)
+		publishedAt, err := time.Parse(time.RFC3339, tweet.CreatedAt)
+		if err != nil {
+			publishedAt = time.Now()
+		}
 
-		// Determine if this is a reply
-		isReply := tweet.ConversationID != "" && tweet.ConversationID != tweet.AuthorID
+		isReply := tweet.InReplyToUserID != ""
+		var isRetweet, isQuote bool
+		for _, ref := range tweet.ReferencedTweets {
+			switch ref.Type {
+			case "retweeted":
+				isRetweet = true
+			case "quoted":
+				isQuote = true
+			}
+		}
 
-		c := Content{
-			ID:          tweet.ID,
-			Title:       fmt.Sprintf("Tweet by @%s", t.config.Username),
-			URL:         fmt.Sprintf("https://twitter.com/%s/status/%s", t.config.Username, tweet.ID),
-			Author:      t.config.Username,
-			Text:        tweet.Text,
-			PublishedAt: publishedAt,
-			Likes:       tweet.PublicMetrics.LikeCount,
-			Retweets:    tweet.PublicMetrics.RetweetCount,
-			Replies:     tweet.PublicMetrics.ReplyCount,
-			QuoteTweets: tweet.PublicMetrics.QuoteCount,
+		title := fmt.Sprintf("Tweet by %s", author)
+		switch {
+		case isRetweet:
+			title = fmt.Sprintf("Retweet by %s", author)
+		case isReply:
+			title = fmt.Sprintf("Reply by %s", author)
 		}
 
-		// Add context to title for replies
-		if isReply {
-			c.Title = fmt.Sprintf("Reply by @%s", t.config.Username)
+		tweetURL := fmt.Sprintf("https://twitter.com/%s/status/%s", t.username, tweet.ID)
+		if t.username == "" {
+			tweetURL = fmt.Sprintf("https://twitter.com/i/web/status/%s", tweet.ID)
 		}
 
-		content = append(content, c)
+		contents = append(contents, model.Content{
+			ID:          t.SourceID() + ":" + tweet.ID,
+			SourceID:    t.SourceID(),
+			Title:       title,
+			Description: tweet.Text,
+			URL:         tweetURL,
+			Author:      author,
+			Platform:    "Twitter",
+			PublishedAt: publishedAt,
+			UpdatedAt:   time.Now(),
+			Metadata: map[string]interface{}{
+				"tweet_id":        tweet.ID,
+				"author_handle":   t.username,
+				"conversation_id": tweet.ConversationID,
+				"is_reply":        isReply,
+				"is_retweet":      isRetweet,
+				"is_quote":        isQuote,
+				"like_count":      tweet.PublicMetrics.LikeCount,
+				"retweet_count":   tweet.PublicMetrics.RetweetCount,
+				"reply_count":     tweet.PublicMetrics.ReplyCount,
+				"quote_count":     tweet.PublicMetrics.QuoteCount,
+			},
+		})
 	}
 
-	return content
+	return contents
 }
 
 // Twitter API response structures
@@ -232,12 +354,19 @@ type twitterResponse struct {
 }
 
 type tweetData struct {
-	ID             string        `json:"id"`
-	Text           string        `json:"text"`
-	AuthorID       string        `json:"author_id"`
-	ConversationID string        `json:"conversation_id"`
-	CreatedAt      string        `json:"created_at"`
-	PublicMetrics  publicMetrics `json:"public_metrics"`
+	ID               string            `json:"id"`
+	Text             string            `json:"text"`
+	AuthorID         string            `json:"author_id"`
+	ConversationID   string            `json:"conversation_id"`
+	InReplyToUserID  string            `json:"in_reply_to_user_id"`
+	ReferencedTweets []referencedTweet `json:"referenced_tweets"`
+	CreatedAt        string            `json:"created_at"`
+	PublicMetrics    publicMetrics     `json:"public_metrics"`
+}
+
+type referencedTweet struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
 }
 
 type publicMetrics struct {
@@ -247,17 +376,7 @@ type publicMetrics struct {
 	QuoteCount   int `json:"quote_count"`
 }
 
-// SetSinceID allows updating the since_id parameter
-func (t *Twitter) SetSinceID(sinceID string) {
-	t.config.SinceID = sinceID
-}
-
-// GetSinceID returns the current since_id
-func (t *Twitter) GetSinceID() string {
-	return t.config.SinceID
-}
-
 // GetUserID returns the resolved User ID
 func (t *Twitter) GetUserID() string {
-	return t.config.UserID
+	return t.userID
 }
@@ -0,0 +1,85 @@
+package twitter
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/ryansiau/KeepUpdated/go/model"
+)
+
+var usernameRegex = regexp.MustCompile(`^[A-Za-z0-9_]{1,15}$`)
+
+// Fetch modes
+const (
+	ModeAPI       = "api"        // the official API v2, requires a bearer token
+	ModeNitter    = "nitter"     // the RSS feed of a Nitter instance
+	ModeRSSBridge = "rss_bridge" // the TwitterBridge of an RSS-Bridge instance
+)
+
+var validModes = []string{ModeAPI, ModeNitter, ModeRSSBridge}
+
+// Config holds Twitter API configuration for a specific user
+type Config struct {
+	// Mode defaults to "api". The feed modes only support usernames.
+	Mode string `yaml:"mode" mapstructure:"mode"`
+	// Instances are the base URLs of the Nitter or RSS-Bridge instances, tried in order until one responds
+	Instances []string `yaml:"instances" mapstructure:"instances"`
+
+	BearerToken     string `yaml:"bearer_token" mapstructure:"bearer_token"`
+	Username        string `yaml:"username" mapstructure:"username"`                 // The Twitter username to monitor
+	UserID          string `yaml:"user_id" mapstructure:"user_id"`                   // Optional: User ID (more reliable than username)
+	MaxResults      int    `yaml:"max_results" mapstructure:"max_results"`           // Max tweets per request (5-100)
+	IncludeRetweets bool   `yaml:"include_retweets" mapstructure:"include_retweets"` // Whether to include retweets
+	IncludeReplies  bool   `yaml:"include_replies" mapstructure:"include_replies"`   // Whether to include replies
+}
+
+// Validate validates the Twitter source configuration
+func (c *Config) Validate() error {
+	if c.Username == "" && c.UserID == "" {
+		return fmt.Errorf("username or user_id is required")
+	}
+	if c.Username != "" && !usernameRegex.MatchString(normalizeUsername(c.Username)) {
+		return fmt.Errorf("invalid username: %s", c.Username)
+	}
+	if c.MaxResults != 0 && (c.MaxResults < 5 || c.MaxResults > 100) {
+		return fmt.Errorf("max_results has to be between 5 and 100")
+	}
+	if c.Mode != "" && !slices.Contains(validModes, c.Mode) {
+		return fmt.Errorf("invalid mode: %s", c.Mode)
+	}
+
+	if c.Mode == "" || c.Mode == ModeAPI {
+		if c.BearerToken == "" {
+			return fmt.Errorf("bearer_token is required")
+		}
+		return nil
+	}
+
+	if c.Username == "" {
+		return fmt.Errorf("username is required in %s mode", c.Mode)
+	}
+	if len(c.Instances) == 0 {
+		return fmt.Errorf("instances are required in %s mode", c.Mode)
+	}
+	for _, instance := range c.Instances {
+		u, err := url.Parse(instance)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("invalid instance URL: %s", instance)
+		}
+	}
+	return nil
+}
+
+func (c *Config) IsCrawler() {}
+
+func (c *Config) Build(name string) (model.Source, error) {
+	return New(c, name), nil
+}
+
+// normalizeUsername strips whitespace and the leading "@" of a username
+func normalizeUsername(username string) string {
+	return strings.TrimPrefix(strings.TrimSpace(username), "@")
+}
@@ -0,0 +1,185 @@
+package twitter
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ryansiau/KeepUpdated/go/common"
+	"github.com/ryansiau/KeepUpdated/go/model"
+	"github.com/ryansiau/KeepUpdated/go/pkg/htmltext"
+	generic_rss "github.com/ryansiau/KeepUpdated/go/source/generic-rss"
+)
+
+// stateInstance keeps the last instance that responded, so it is tried first on the next execution
+const stateInstance = "instance"
+
+var statusRegex = regexp.MustCompile(`/status(?:es)?/(\d+)`)
+
+// fetchFeed retrieves the posts from the first Nitter or RSS-Bridge instance that responds
+func (t *Twitter) fetchFeed(ctx context.Context) ([]model.Content, error) {
+	instances := slices.Clone(t.config.Instances)
+
+	// try the instance that worked last time first
+	if idx := slices.Index(instances, t.instance); idx > 0 {
+		instances = append([]string{t.instance}, slices.Delete(instances, idx, idx+1)...)
+	}
+
+	var errs []error
+	for _, instance := range instances {
+		feed, err := t.fetchInstance(ctx, instance)
+		if err != nil {
+			logrus.WithField("source", t.name).Warnf("Instance %s failed: %v", instance, err)
+			errs = append(errs, fmt.Errorf("%s: %w", instance, err))
+			continue
+		}
+
+		t.instance = instance
+		return t.parseFeed(feed, instance), nil
+	}
+
+	return nil, fmt.Errorf("all instances failed: %w", errors.Join(errs...))
+}
+
+// feedURL returns the URL of the user's feed on the instance
+func (t *Twitter) feedURL(instance string) string {
+	instance = strings.TrimSuffix(instance, "/")
+
+	if t.config.Mode == ModeRSSBridge {
+		params := url.Values{}
+		params.Set("action", "display")
+		params.Set("bridge", "TwitterBridge")
+		params.Set("context", "By username")
+		params.Set("u", t.username)
+		params.Set("format", "Mrss")
+		if !t.config.IncludeReplies {
+			params.Set("norep", "on")
+		}
+		if !t.config.IncludeRetweets {
+			params.Set("noretweet", "on")
+		}
+		return instance + "/?" + params.Encode()
+	}
+
+	if t.config.IncludeReplies {
+		return instance + "/" + url.PathEscape(t.username) + "/with_replies/rss"
+	}
+	return instance + "/" + url.PathEscape(t.username) + "/rss"
+}
+
+func (t *Twitter) fetchInstance(ctx context.Context, instance string) (*generic_rss.RSSFeed, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", t.feedURL(instance), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/rss+xml, application/xml, text/xml")
+	req.Header.Set("User-Agent", common.HTTPClientUserAgent)
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("returned status: %d", resp.StatusCode)
+	}
+
+	var feed generic_rss.RSSFeed
+	if err := xml.NewDecoder(resp.Body).Decode(&feed); err != nil {
+		return nil, fmt.Errorf("failed to decode feed: %w", err)
+	}
+
+	return &feed, nil
+}
+
+// parseFeed converts the feed items into the same Content shape as the API
+func (t *Twitter) parseFeed(feed *generic_rss.RSSFeed, instance string) []model.Content {
+	var contents []model.Content
+	seen := map[string]struct{}{}
+
+	for _, item := range feed.Channel.Items {
+		match := statusRegex.FindStringSubmatch(item.Link)
+		if match == nil {
+			match = statusRegex.FindStringSubmatch(item.GUID)
+		}
+		if match == nil {
+			continue
+		}
+		tweetID := match[1]
+
+		title := strings.TrimSpace(item.Title)
+
+		// Nitter prefixes the titles of retweets with "RT by @user:" and the replies with "R to @user:".
+		// the creator of a retweet is the original author.
+		creator := strings.TrimPrefix(strings.TrimSpace(item.Creator), "@")
+		isRetweet := strings.HasPrefix(title, "RT by @") || strings.HasPrefix(title, "RT @") ||
+			(creator != "" && !strings.EqualFold(creator, t.username))
+		isReply := strings.HasPrefix(title, "R to @")
+
+		if isRetweet && !t.config.IncludeRetweets {
+			continue
+		}
+		if isReply && !t.config.IncludeReplies {
+			continue
+		}
+
+		// a retweet of the user's own tweet links to the tweet itself
+		if _, ok := seen[tweetID]; ok {
+			continue
+		}
+		seen[tweetID] = struct{}{}
+
+		publishedAt, err := generic_rss.ParseDate(strings.TrimSpace(item.PubDate))
+		if err != nil {
+			publishedAt = time.Now()
+		}
+
+		author := "@" + t.username
+
+		contentTitle := fmt.Sprintf("Tweet by %s", author)
+		switch {
+		case isRetweet:
+			contentTitle = fmt.Sprintf("Retweet by %s", author)
+		case isReply:
+			contentTitle = fmt.Sprintf("Reply by %s", author)
+		}
+
+		// the descriptions of Nitter and RSS-Bridge are the HTML of the tweet
+		description := title
+		if text := htmltext.ToText(item.Description); text != "" {
+			description = text
+		}
+
+		contents = append(contents, model.Content{
+			ID:          t.SourceID() + ":" + tweetID,
+			SourceID:    t.SourceID(),
+			Title:       contentTitle,
+			Description: description,
+			URL:         fmt.Sprintf("https://twitter.com/%s/status/%s", t.username, tweetID),
+			Author:      author,
+			Platform:    "Twitter",
+			PublishedAt: publishedAt,
+			UpdatedAt:   time.Now(),
+			Metadata: map[string]interface{}{
+				"tweet_id":      tweetID,
+				"author_handle": t.username,
+				"is_reply":      isReply,
+				"is_retweet":    isRetweet,
+				"instance":      instance,
+			},
+		})
+	}
+
+	return contents
+}
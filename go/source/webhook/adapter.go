@@ -0,0 +1,147 @@
+// Package webhook receives contents pushed over HTTP, e.g. by CI systems and scripts.
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/ryansiau/KeepUpdated/go/model"
+)
+
+// Adapter implements the InboundSource interface for webhooks
+type Adapter struct {
+	config *Config
+	name   string
+}
+
+var _ model.InboundSource = (*Adapter)(nil)
+
+// NewAdapter creates a new webhook adapter with configuration, the name identifies the source
+func NewAdapter(config *Config, name string) (*Adapter, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if name == "" {
+		return nil, fmt.Errorf("webhook sources require a name")
+	}
+
+	return &Adapter{
+		config: config,
+		name:   name,
+	}, nil
+}
+
+// Name returns the name of the source
+func (a *Adapter) Name() string {
+	return a.name
+}
+
+// Type returns the platform type
+func (a *Adapter) Type() string {
+	return "webhook"
+}
+
+// SourceID returns the identifier of the source
+func (a *Adapter) SourceID() string {
+	return "Webhook:" + a.name
+}
+
+// Fetch returns nothing, the contents of webhooks are received instead
+func (a *Adapter) Fetch(ctx context.Context) ([]model.Content, error) {
+	return nil, nil
+}
+
+// Receive authenticates the request and maps its JSON body into contents
+func (a *Adapter) Receive(ctx context.Context, header http.Header, body []byte) ([]model.Content, error) {
+	if err := a.authenticate(header, body); err != nil {
+		return nil, err
+	}
+
+	var data interface{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return nil, fmt.Errorf("failed to decode payload: %w", err)
+	}
+
+	// a payload without items is a single item
+	if a.config.Items == "" {
+		if _, ok := data.([]interface{}); !ok {
+			data = []interface{}{data}
+		}
+	}
+
+	// without an id mapping every item shares the placeholder id, they're told apart by their index instead
+	var seen map[string]struct{}
+	if a.config.ID != "" {
+		seen = map[string]struct{}{}
+	}
+
+	contents, err := a.config.mapping().Contents(data, a.SourceID(), "Webhook", seen)
+	if err != nil {
+		return nil, err
+	}
+
+	if a.config.ID == "" {
+		delivery, err := deliveryID(header)
+		if err != nil {
+			return nil, err
+		}
+		for i := range contents {
+			contents[i].ID = fmt.Sprintf("%s:%s-%d", a.SourceID(), delivery, i)
+		}
+	}
+
+	if a.config.Title == "" {
+		for i := range contents {
+			contents[i].Title = a.name
+		}
+	}
+
+	return contents, nil
+}
+
+// authenticate checks the signature of the body, or the bearer token
+func (a *Adapter) authenticate(header http.Header, body []byte) error {
+	if a.config.Secret != "" {
+		signature := strings.TrimPrefix(header.Get(a.config.signatureHeader()), "sha256=")
+		expected, err := hex.DecodeString(signature)
+		if err != nil || signature == "" {
+			return fmt.Errorf("%w: missing or malformed %s", model.ErrUnauthorized, a.config.signatureHeader())
+		}
+
+		mac := hmac.New(sha256.New, []byte(a.config.Secret))
+		mac.Write(body)
+		if !hmac.Equal(mac.Sum(nil), expected) {
+			return fmt.Errorf("%w: invalid signature", model.ErrUnauthorized)
+		}
+		return nil
+	}
+
+	token, found := strings.CutPrefix(header.Get("Authorization"), "Bearer ")
+	if !found || subtle.ConstantTimeCompare([]byte(token), []byte(a.config.Token)) != 1 {
+		return fmt.Errorf("%w: invalid token", model.ErrUnauthorized)
+	}
+	return nil
+}
+
+// deliveryID returns the id the sender gave to the delivery, a random one when there is none
+func deliveryID(header http.Header) (string, error) {
+	for _, key := range []string{"X-Request-Id", "X-GitHub-Delivery", "X-Gitea-Delivery", "X-Gitlab-Event-UUID"} {
+		if id := header.Get(key); id != "" {
+			return id, nil
+		}
+	}
+
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,78 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/ryansiau/KeepUpdated/go/model"
+	json_api "github.com/ryansiau/KeepUpdated/go/source/json-api"
+)
+
+const payload = `{"items": [{"name": "a"}, {"name": "b"}, {"name": "c"}, {"name": "a"}]}`
+
+// signed returns the headers of a delivery of body signed with secret
+func signed(body, secret string) http.Header {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+
+	header := http.Header{}
+	header.Set(defaultSignatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	header.Set("X-GitHub-Delivery", "d1")
+	return header
+}
+
+func TestReceiveWithoutID(t *testing.T) {
+	adapter, err := NewAdapter(&Config{Secret: "secret", Mapping: json_api.Mapping{Items: "items", Title: "name"}}, "deploys")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	contents, err := adapter.Receive(context.Background(), signed(payload, "secret"), []byte(payload))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"Webhook:deploys:d1-0", "Webhook:deploys:d1-1", "Webhook:deploys:d1-2", "Webhook:deploys:d1-3"}
+	if len(contents) != len(want) {
+		t.Fatalf("got %d contents, want every item of the delivery", len(contents))
+	}
+	for idx, content := range contents {
+		if content.ID != want[idx] {
+			t.Errorf("content %d: ID = %q, want %q", idx, content.ID, want[idx])
+		}
+	}
+}
+
+func TestReceiveWithID(t *testing.T) {
+	adapter, err := NewAdapter(&Config{Secret: "secret", Mapping: json_api.Mapping{Items: "items", ID: "name"}}, "deploys")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	contents, err := adapter.Receive(context.Background(), signed(payload, "secret"), []byte(payload))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"Webhook:deploys:a", "Webhook:deploys:b", "Webhook:deploys:c"}
+	if len(contents) != len(want) {
+		t.Fatalf("got %d contents, want the repeated id to be dropped", len(contents))
+	}
+	for idx, content := range contents {
+		if content.ID != want[idx] {
+			t.Errorf("content %d: ID = %q, want %q", idx, content.ID, want[idx])
+		}
+		if content.Title != "deploys" {
+			t.Errorf("content %d: Title = %q, want the name of the source", idx, content.Title)
+		}
+	}
+
+	if _, err := adapter.Receive(context.Background(), signed(payload, "other"), []byte(payload)); !errors.Is(err, model.ErrUnauthorized) {
+		t.Errorf("got %v for a bad signature, want ErrUnauthorized", err)
+	}
+}
@@ -0,0 +1,55 @@
+package webhook
+
+import (
+	"fmt"
+
+	"github.com/ryansiau/KeepUpdated/go/model"
+	json_api "github.com/ryansiau/KeepUpdated/go/source/json-api"
+)
+
+// defaultSignatureHeader is the header carrying the HMAC when signature_header isn't configured
+const defaultSignatureHeader = "X-Hub-Signature-256"
+
+// Config represents the configuration for a webhook source, served on /hooks/<workflow>
+type Config struct {
+	// Secret verifies the HMAC-SHA256 of the body, sent as hex with an optional "sha256=" prefix
+	Secret          string `yaml:"secret" mapstructure:"secret"`
+	SignatureHeader string `yaml:"signature_header" mapstructure:"signature_header"`
+	// Token is expected as "Authorization: Bearer <token>" instead, for senders that can't sign
+	Token string `yaml:"token" mapstructure:"token"`
+
+	// Mapping converts the payload, a payload without items is a single item.
+	// without id, every request is a new content.
+	json_api.Mapping `yaml:",inline" mapstructure:",squash"`
+}
+
+// Validate validates the webhook source configuration
+func (c *Config) Validate() error {
+	if c.Secret == "" && c.Token == "" {
+		return fmt.Errorf("secret or token is required")
+	}
+	return c.mapping().Validate()
+}
+
+func (c *Config) IsCrawler() {}
+
+func (c *Config) Build(name string) (model.Source, error) {
+	return NewAdapter(c, name)
+}
+
+func (c *Config) signatureHeader() string {
+	if c.SignatureHeader == "" {
+		return defaultSignatureHeader
+	}
+	return c.SignatureHeader
+}
+
+// mapping returns the configured mapping. without an id expression, every item gets a placeholder id
+// replaced by the id of the delivery.
+func (c *Config) mapping() *json_api.Mapping {
+	mapping := c.Mapping
+	if mapping.ID == "" {
+		mapping.ID = "`\"delivery\"`"
+	}
+	return &mapping
+}
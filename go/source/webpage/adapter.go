@@ -0,0 +1,140 @@
+// Package webpage watches pages without a feed, either as a list of items or for changes of a region.
+package webpage
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"resty.dev/v3"
+
+	"github.com/ryansiau/KeepUpdated/go/common"
+	"github.com/ryansiau/KeepUpdated/go/model"
+)
+
+// Keys of the state stored between executions
+const (
+	stateHash     = "hash"
+	stateSnapshot = "snapshot"
+	stateChanges  = "changes"
+)
+
+// Adapter implements the Source interface for web pages
+type Adapter struct {
+	client *resty.Client
+	config *Config
+	name   string
+
+	// hash and snapshot are the text of the watched region on the last execution
+	hash     string
+	snapshot string
+	// changes counts the detected changes, so a region going back to an earlier text is a new change
+	changes int
+}
+
+var _ model.StatefulSource = (*Adapter)(nil)
+
+// NewAdapter creates a new web page adapter with configuration
+func NewAdapter(config *Config, name string) (*Adapter, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	if name == "" {
+		name = "Web page: " + config.URL
+	}
+
+	client := resty.New().
+		SetTimeout(30*time.Second).
+		SetHeader("User-Agent", common.HTTPClientUserAgent)
+
+	return &Adapter{
+		client: client,
+		config: config,
+		name:   name,
+	}, nil
+}
+
+// Name returns the name of the source
+func (a *Adapter) Name() string {
+	return a.name
+}
+
+// Type returns the platform type
+func (a *Adapter) Type() string {
+	return "webpage"
+}
+
+// SourceID returns the identifier of the source
+func (a *Adapter) SourceID() string {
+	if a.config.Item != "" {
+		return fmt.Sprintf("Webpage:%s#items(%s)", a.config.URL, a.config.Item)
+	}
+	return fmt.Sprintf("Webpage:%s#%s", a.config.URL, a.config.selector())
+}
+
+// LoadState restores the snapshot of the watched region
+func (a *Adapter) LoadState(state map[string]string) {
+	a.hash = state[stateHash]
+	a.snapshot = state[stateSnapshot]
+	a.changes, _ = strconv.Atoi(state[stateChanges])
+}
+
+// State returns the snapshot of the watched region, items are tracked by the worker instead
+func (a *Adapter) State() map[string]string {
+	if a.hash == "" {
+		return nil
+	}
+	return map[string]string{
+		stateHash:     a.hash,
+		stateSnapshot: a.snapshot,
+		stateChanges:  strconv.Itoa(a.changes),
+	}
+}
+
+// Fetch retrieves the page, then its items or the change of the watched region
+func (a *Adapter) Fetch(ctx context.Context) ([]model.Content, error) {
+	doc, err := a.fetchDocument(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if a.config.Item != "" {
+		return a.extractItems(doc), nil
+	}
+	return a.detectChange(doc)
+}
+
+func (a *Adapter) fetchDocument(ctx context.Context) (*goquery.Document, error) {
+	resp, err := a.client.R().
+		SetContext(ctx).
+		SetHeader("Accept", "text/html,application/xhtml+xml").
+		SetDoNotParseResponse(true).
+		Get(a.config.URL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode() != 200 {
+		return nil, fmt.Errorf("status code: %d", resp.StatusCode())
+	}
+
+	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse page: %w", err)
+	}
+
+	return doc, nil
+}
+
+// pageTitle returns the title of the page, the URL when it has none
+func (a *Adapter) pageTitle(doc *goquery.Document) string {
+	if title := strings.TrimSpace(doc.Find("title").First().Text()); title != "" {
+		return title
+	}
+	return a.config.URL
+}
@@ -0,0 +1,99 @@
+package webpage
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/sergi/go-diff/diffmatchpatch"
+
+	"github.com/ryansiau/KeepUpdated/go/model"
+	"github.com/ryansiau/KeepUpdated/go/pkg/htmltext"
+)
+
+// maxDiffLength caps the diff put in the description, notifiers have their own limits too
+const maxDiffLength = 3000
+
+// detectChange compares the text of the watched region with the snapshot of the last execution.
+// the first execution only takes the snapshot.
+func (a *Adapter) detectChange(doc *goquery.Document) ([]model.Content, error) {
+	selection := doc.Find(a.config.selector())
+	if selection.Length() == 0 {
+		return nil, fmt.Errorf("selector %q matched nothing", a.config.selector())
+	}
+
+	var parts []string
+	selection.Each(func(_ int, s *goquery.Selection) {
+		html, err := goquery.OuterHtml(s)
+		if err == nil {
+			parts = append(parts, htmltext.ToText(html))
+		}
+	})
+	text := strings.Join(parts, "\n\n")
+
+	sum := sha256.Sum256([]byte(text))
+	hash := hex.EncodeToString(sum[:])
+
+	previousHash, previous := a.hash, a.snapshot
+	a.hash, a.snapshot = hash, text
+
+	if previousHash == "" || previousHash == hash {
+		return nil, nil
+	}
+	a.changes++
+
+	return []model.Content{{
+		ID:          fmt.Sprintf("%s:%d@%s", a.SourceID(), a.changes, hash[:16]),
+		SourceID:    a.SourceID(),
+		Title:       fmt.Sprintf("%s changed", a.pageTitle(doc)),
+		Description: lineDiff(previous, text),
+		URL:         a.config.URL,
+		Author:      a.config.URL,
+		Platform:    "Webpage",
+		PublishedAt: time.Now(),
+		UpdatedAt:   time.Now(),
+		Metadata: map[string]interface{}{
+			"page":          a.config.URL,
+			"selector":      a.config.selector(),
+			"hash":          hash,
+			"previous_hash": previousHash,
+			"change":        a.changes,
+		},
+	}}, nil
+}
+
+// lineDiff lists the removed lines prefixed with "-" and the added ones with "+"
+func lineDiff(before, after string) string {
+	dmp := diffmatchpatch.New()
+	chars1, chars2, lines := dmp.DiffLinesToChars(before, after)
+	diffs := dmp.DiffCharsToLines(dmp.DiffMain(chars1, chars2, false), lines)
+
+	var sb strings.Builder
+	for _, diff := range diffs {
+		prefix := ""
+		switch diff.Type {
+		case diffmatchpatch.DiffInsert:
+			prefix = "+ "
+		case diffmatchpatch.DiffDelete:
+			prefix = "- "
+		default:
+			continue
+		}
+
+		for _, line := range strings.Split(strings.TrimSuffix(diff.Text, "\n"), "\n") {
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+			sb.WriteString(prefix + line + "\n")
+		}
+	}
+
+	result := strings.TrimSpace(sb.String())
+	if len(result) > maxDiffLength {
+		result = strings.ToValidUTF8(result[:maxDiffLength], "") + "\n…"
+	}
+	return result
+}
@@ -0,0 +1,68 @@
+package webpage
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/andybalholm/cascadia"
+
+	"github.com/ryansiau/KeepUpdated/go/model"
+)
+
+// Config represents the configuration for a web page source.
+// with item set, every matched item is a content. otherwise the text of selector is watched for changes.
+type Config struct {
+	URL string `yaml:"url" mapstructure:"url"`
+
+	// Selector is the region watched for changes, the whole body by default
+	Selector string `yaml:"selector" mapstructure:"selector"`
+
+	// Item selects the container of every item of a list, Title, Link and Date are relative to it.
+	// the title defaults to the text of the item and the link to its first anchor.
+	Item  string `yaml:"item" mapstructure:"item"`
+	Title string `yaml:"title" mapstructure:"title"`
+	Link  string `yaml:"link" mapstructure:"link"`
+	Date  string `yaml:"date" mapstructure:"date"`
+	// DateFormat is the Go layout of the date, e.g. "January 2, 2006". common formats are tried without it.
+	DateFormat string `yaml:"date_format" mapstructure:"date_format"`
+}
+
+// Validate validates the web page source configuration
+func (c *Config) Validate() error {
+	u, err := url.Parse(c.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("url has to be an absolute http(s) URL: %s", c.URL)
+	}
+
+	if c.Item != "" && c.Selector != "" {
+		return fmt.Errorf("selector and item can't be set together")
+	}
+	if c.Item == "" && (c.Title != "" || c.Link != "" || c.Date != "") {
+		return fmt.Errorf("title, link and date selectors require item")
+	}
+
+	for _, selector := range []string{c.Selector, c.Item, c.Title, c.Link, c.Date} {
+		if selector == "" {
+			continue
+		}
+		if _, err := cascadia.Compile(selector); err != nil {
+			return fmt.Errorf("invalid selector %q: %w", selector, err)
+		}
+	}
+
+	return nil
+}
+
+func (c *Config) IsCrawler() {}
+
+func (c *Config) Build(name string) (model.Source, error) {
+	return NewAdapter(c, name)
+}
+
+// selector returns the watched region, the body by default
+func (c *Config) selector() string {
+	if c.Selector == "" {
+		return "body"
+	}
+	return c.Selector
+}
@@ -0,0 +1,140 @@
+package webpage
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+
+	"github.com/ryansiau/KeepUpdated/go/model"
+	generic_rss "github.com/ryansiau/KeepUpdated/go/source/generic-rss"
+)
+
+// maxItems is the number of items returned on every fetch
+const maxItems = 50
+
+// extractItems converts every item of the list into a content, in the order of the page
+func (a *Adapter) extractItems(doc *goquery.Document) []model.Content {
+	base, _ := url.Parse(a.config.URL)
+	now := time.Now()
+
+	var contents []model.Content
+	seen := map[string]struct{}{}
+	doc.Find(a.config.Item).EachWithBreak(func(_ int, item *goquery.Selection) bool {
+		title := collapse(item.Text())
+		if a.config.Title != "" {
+			title = collapse(item.Find(a.config.Title).First().Text())
+		}
+
+		link := a.findLink(item, base)
+
+		// items without a link are told apart by their title
+		key := link
+		if key == "" {
+			if title == "" {
+				return true
+			}
+			sum := sha256.Sum256([]byte(title))
+			key = hex.EncodeToString(sum[:8])
+		}
+
+		// the image and the title of an item often both link to it
+		if _, ok := seen[key]; ok {
+			return true
+		}
+		seen[key] = struct{}{}
+
+		publishedAt := now
+		if a.config.Date != "" {
+			if date, ok := a.parseDate(item.Find(a.config.Date).First()); ok {
+				publishedAt = date
+			}
+		}
+
+		if link == "" {
+			link = a.config.URL
+		}
+
+		contents = append(contents, model.Content{
+			ID:          a.SourceID() + ":" + key,
+			SourceID:    a.SourceID(),
+			Title:       title,
+			URL:         link,
+			Author:      base.Host,
+			Platform:    "Webpage",
+			PublishedAt: publishedAt,
+			UpdatedAt:   now,
+			Metadata: map[string]interface{}{
+				"page": a.config.URL,
+			},
+		})
+
+		return len(contents) < maxItems
+	})
+
+	// newest first, undated items share the same time so they keep the order of the page
+	slices.SortStableFunc(contents, func(a, b model.Content) int {
+		return b.PublishedAt.Compare(a.PublishedAt)
+	})
+
+	return contents
+}
+
+// findLink returns the absolute link of the item: the link selector, the item itself when it's an anchor, or its first anchor
+func (a *Adapter) findLink(item *goquery.Selection, base *url.URL) string {
+	var anchor *goquery.Selection
+	switch {
+	case a.config.Link != "":
+		anchor = item.Find(a.config.Link).First()
+	case goquery.NodeName(item) == "a":
+		anchor = item
+	default:
+		anchor = item.Find("a[href]").First()
+	}
+
+	href, ok := anchor.Attr("href")
+	if !ok || strings.TrimSpace(href) == "" {
+		return ""
+	}
+
+	ref, err := url.Parse(strings.TrimSpace(href))
+	if err != nil {
+		return ""
+	}
+	return base.ResolveReference(ref).String()
+}
+
+// parseDate reads the datetime attribute of <time> elements, the text otherwise
+func (a *Adapter) parseDate(selection *goquery.Selection) (time.Time, bool) {
+	value, ok := selection.Attr("datetime")
+	if !ok {
+		value = collapse(selection.Text())
+	}
+	if value == "" {
+		return time.Time{}, false
+	}
+
+	if a.config.DateFormat != "" {
+		date, err := time.Parse(a.config.DateFormat, value)
+		return date, err == nil
+	}
+
+	if date, err := generic_rss.ParseDate(value); err == nil {
+		return date, true
+	}
+	for _, layout := range []string{"2006-01-02", "2006-01-02 15:04:05", "January 2, 2006", "Jan 2, 2006", "2 January 2006"} {
+		if date, err := time.Parse(layout, value); err == nil {
+			return date, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// collapse joins the whitespace of a text into single spaces
+func collapse(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
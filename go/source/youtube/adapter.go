@@ -3,6 +3,8 @@ package youtube
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/ryansiau/KeepUpdated/go/common"
@@ -12,10 +14,18 @@ import (
 	"google.golang.org/api/youtube/v3"
 )
 
+// maxResults is the number of latest videos returned on every fetch
+const maxResults = 25
+
 type Adapter struct {
-	name      string
-	channelID string
-	client    *youtube.Service
+	name       string
+	channelID  string
+	handle     string
+	playlistID string
+	client     *youtube.Service
+
+	includeKinds []string
+	excludeKinds []string
 }
 
 func NewAdapter(conf *Config, name string) (model.Source, error) {
@@ -28,21 +38,35 @@ func NewAdapter(conf *Config, name string) (model.Source, error) {
 		return nil, fmt.Errorf("failed to create YouTube client: %w", err)
 	}
 
+	handle := normalizeHandle(conf.Handle)
+
 	if name == "" {
-		name = "Youtube: " + conf.ChannelID
+		switch {
+		case conf.PlaylistID != "":
+			name = "Youtube: playlist " + conf.PlaylistID
+		case handle != "":
+			name = "Youtube: @" + handle
+		default:
+			name = "Youtube: " + conf.ChannelID
+		}
 	}
 
 	return &Adapter{
-		name:      name,
-		channelID: conf.ChannelID,
-		client:    client,
+		name:       name,
+		channelID:  conf.ChannelID,
+		handle:     handle,
+		playlistID: conf.PlaylistID,
+		client:     client,
+
+		includeKinds: conf.IncludeKinds,
+		excludeKinds: conf.ExcludeKinds,
 	}, nil
 }
 
 func (a *Adapter) FetchVideos(channelID string) ([]*youtube.Video, error) {
 	call := a.client.Search.List([]string{"snippet"})
 	call.ChannelId(channelID)
-	call.MaxResults(25)
+	call.MaxResults(maxResults)
 	call.Order("date")
 
 	response, err := call.Do()
@@ -81,19 +105,83 @@ func (a *Adapter) Type() string {
 }
 
 func (a *Adapter) Fetch(ctx context.Context) ([]model.Content, error) {
-	videos, err := a.FetchVideos(a.channelID)
+	var videos []*youtube.Video
+	var err error
+
+	if a.playlistID != "" {
+		videos, err = a.FetchPlaylistVideos(ctx, a.playlistID)
+	} else {
+		channelID := a.channelID
+		if a.handle != "" {
+			channelID, err = a.ResolveHandle(ctx, a.handle)
+			if err != nil {
+				return nil, err
+			}
+		}
+		videos, err = a.FetchVideos(channelID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var videoIDs []string
+	for _, video := range videos {
+		videoIDs = append(videoIDs, video.Id)
+	}
+
+	return a.videoContents(ctx, videoIDs)
+}
+
+// videoContents converts the videos into contents, skipping the kinds that aren't followed
+func (a *Adapter) videoContents(ctx context.Context, videoIDs []string) ([]model.Content, error) {
+	// search and playlist results lack the duration and broadcast details needed to tell the kinds apart
+	videos, err := a.FetchVideoDetails(ctx, videoIDs)
 	if err != nil {
 		return nil, err
 	}
 
 	var contents []model.Content
 	for _, video := range videos {
+		kind := classify(video)
+		if !a.allowKind(kind) {
+			continue
+		}
+
 		publishedAt, err := time.Parse(time.RFC3339, video.Snippet.PublishedAt)
 		if err != nil {
 			publishedAt = time.Now()
 		}
+
+		metadata := map[string]interface{}{
+			"video_id":   video.Id,
+			"channel_id": video.Snippet.ChannelId,
+			"kind":       kind,
+		}
+		if a.playlistID != "" {
+			metadata["playlist_id"] = a.playlistID
+		}
+		if video.ContentDetails != nil {
+			metadata["duration"] = video.ContentDetails.Duration
+		}
+
+		// upcoming streams and premieres get their own id, so the same video
+		// is notified again once it actually goes live
+		id := a.contentID(video.Id)
+		if isUpcoming(video) {
+			id += ":upcoming"
+		}
+
+		if details := video.LiveStreamingDetails; details != nil {
+			if details.ScheduledStartTime != "" {
+				metadata["scheduled_start"] = details.ScheduledStartTime
+			}
+			if startedAt, err := time.Parse(time.RFC3339, details.ActualStartTime); err == nil {
+				publishedAt = startedAt
+			}
+		}
+
 		contents = append(contents, model.Content{
-			ID:          video.Id,
+			ID:          id,
 			SourceID:    a.SourceID(),
 			Title:       video.Snippet.Title,
 			Description: video.Snippet.Description,
@@ -102,15 +190,41 @@ func (a *Adapter) Fetch(ctx context.Context) ([]model.Content, error) {
 			Platform:    "YouTube",
 			PublishedAt: publishedAt,
 			UpdatedAt:   time.Now(),
-			Metadata: map[string]interface{}{
-				"video_id": video.Id,
-			},
+			Metadata:    metadata,
 		})
 	}
 
 	return contents, nil
 }
 
+// contentID namespaces the video id by the source, as a video can be in several sources,
+// e.g. a channel and its uploads playlist. channel sources keep the bare video ids they've always stored,
+// two of them never share a video without sharing their source id.
+func (a *Adapter) contentID(videoID string) string {
+	if a.playlistID == "" && a.handle == "" {
+		return videoID
+	}
+	return a.SourceID() + ":" + videoID
+}
+
+// allowKind checks the kind against the include_kinds and exclude_kinds options
+func (a *Adapter) allowKind(kind string) bool {
+	if len(a.includeKinds) > 0 && !slices.Contains(a.includeKinds, kind) {
+		return false
+	}
+	return !slices.Contains(a.excludeKinds, kind)
+}
+
+// SourceID returns the identifier of the source.
+// channel sources keep the original "Youtube:<channel id>" format,
+// handles and playlists are prefixed so they never collide with a channel id.
 func (a *Adapter) SourceID() string {
-	return fmt.Sprintf("Youtube:%s", a.channelID)
+	switch {
+	case a.playlistID != "":
+		return fmt.Sprintf("Youtube:playlist:%s", a.playlistID)
+	case a.handle != "":
+		return fmt.Sprintf("Youtube:@%s", strings.ToLower(a.handle))
+	default:
+		return fmt.Sprintf("Youtube:%s", a.channelID)
+	}
 }
@@ -0,0 +1,36 @@
+package youtube
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// handleCache keeps resolved handles for the lifetime of the process.
+// adapters are rebuilt on every execution, so the cache can't live in the Adapter itself.
+var handleCache sync.Map
+
+// ResolveHandle resolves a channel handle (without the leading "@") into its channel ID
+func (a *Adapter) ResolveHandle(ctx context.Context, handle string) (string, error) {
+	key := strings.ToLower(handle)
+	if channelID, ok := handleCache.Load(key); ok {
+		return channelID.(string), nil
+	}
+
+	response, err := a.client.Channels.List([]string{"id"}).
+		ForHandle(handle).
+		Context(ctx).
+		Do()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve handle @%s: %w", handle, err)
+	}
+	if len(response.Items) == 0 {
+		return "", fmt.Errorf("no channel found for handle @%s", handle)
+	}
+
+	channelID := response.Items[0].Id
+	handleCache.Store(key, channelID)
+
+	return channelID, nil
+}
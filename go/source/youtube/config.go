@@ -2,20 +2,49 @@ package youtube
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/ryansiau/KeepUpdated/go/model"
 )
 
-// Config represents the configuration for a YouTube source
+// Config represents the configuration for a YouTube source.
+// Exactly one of ChannelID, Handle or PlaylistID has to be set.
 type Config struct {
-	ChannelID string `yaml:"channel_id" mapstructure:"channel_id"`
-	APIKey    string `yaml:"api_key"`
+	ChannelID  string `yaml:"channel_id" mapstructure:"channel_id"`
+	Handle     string `yaml:"handle" mapstructure:"handle"`           // e.g. @GoogleDevelopers, resolved into a channel id
+	PlaylistID string `yaml:"playlist_id" mapstructure:"playlist_id"` // follow a single playlist instead of a channel
+	APIKey     string `yaml:"api_key" mapstructure:"api_key"`
+
+	// IncludeKinds and ExcludeKinds limit which kinds of videos are returned,
+	// any of: video, short, live, upcoming, premiere. Everything is included by default.
+	IncludeKinds []string `yaml:"include_kinds" mapstructure:"include_kinds"`
+	ExcludeKinds []string `yaml:"exclude_kinds" mapstructure:"exclude_kinds"`
 }
 
 // Validate validates the YouTube source configuration
 func (y *Config) Validate() error {
-	if y.ChannelID == "" {
-		return fmt.Errorf("channel_id is required")
+	set := 0
+	for _, v := range []string{y.ChannelID, y.Handle, y.PlaylistID} {
+		if v != "" {
+			set++
+		}
+	}
+
+	if set == 0 {
+		return fmt.Errorf("one of channel_id, handle or playlist_id is required")
+	}
+	if set > 1 {
+		return fmt.Errorf("only one of channel_id, handle or playlist_id can be set")
+	}
+	if strings.ContainsAny(normalizeHandle(y.Handle), " /?#@") {
+		return fmt.Errorf("invalid handle: %s", y.Handle)
+	}
+
+	for _, kind := range append(slices.Clone(y.IncludeKinds), y.ExcludeKinds...) {
+		if !slices.Contains(validKinds, kind) {
+			return fmt.Errorf("invalid video kind: %s", kind)
+		}
 	}
 	return nil
 }
@@ -25,3 +54,8 @@ func (y *Config) IsCrawler() {}
 func (c *Config) Build(name string) (model.Source, error) {
 	return NewAdapter(c, name)
 }
+
+// normalizeHandle strips whitespace and the leading "@" of a handle
+func normalizeHandle(handle string) string {
+	return strings.TrimPrefix(strings.TrimSpace(handle), "@")
+}
@@ -0,0 +1,81 @@
+package youtube
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"google.golang.org/api/youtube/v3"
+)
+
+// maxPlaylistPages caps how many pages of a playlist are read on every fetch.
+// playlists are ordered by position instead of date, so new videos may be added at the end.
+const maxPlaylistPages = 10
+
+func (a *Adapter) FetchPlaylistVideos(ctx context.Context, playlistID string) ([]*youtube.Video, error) {
+	var items []*youtube.PlaylistItem
+
+	pageToken := ""
+	for page := 0; page < maxPlaylistPages; page++ {
+		call := a.client.PlaylistItems.List([]string{"snippet", "contentDetails"})
+		call.PlaylistId(playlistID)
+		call.MaxResults(50)
+		call.Context(ctx)
+		if pageToken != "" {
+			call.PageToken(pageToken)
+		}
+
+		response, err := call.Do()
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch playlist items: %w", err)
+		}
+
+		items = append(items, response.Items...)
+
+		pageToken = response.NextPageToken
+		if pageToken == "" {
+			break
+		}
+	}
+
+	var videos []*youtube.Video
+	for _, item := range items {
+		if item.Snippet == nil || item.Snippet.ResourceId == nil || item.Snippet.ResourceId.Kind != "youtube#video" {
+			continue
+		}
+
+		// private and deleted videos are still listed, but without an owner
+		if item.Snippet.VideoOwnerChannelId == "" {
+			continue
+		}
+
+		publishedAt := item.Snippet.PublishedAt
+		if item.ContentDetails != nil && item.ContentDetails.VideoPublishedAt != "" {
+			publishedAt = item.ContentDetails.VideoPublishedAt
+		}
+
+		videos = append(videos, &youtube.Video{
+			Id: item.Snippet.ResourceId.VideoId,
+			Snippet: &youtube.VideoSnippet{
+				ChannelId:    item.Snippet.VideoOwnerChannelId,
+				ChannelTitle: item.Snippet.VideoOwnerChannelTitle,
+				Description:  item.Snippet.Description,
+				PublishedAt:  publishedAt,
+				Title:        item.Snippet.Title,
+			},
+		})
+	}
+
+	// newest first, the same order as the channel search.
+	// the API always returns RFC3339 timestamps in UTC, which sort lexicographically
+	slices.SortStableFunc(videos, func(a, b *youtube.Video) int {
+		return strings.Compare(b.Snippet.PublishedAt, a.Snippet.PublishedAt)
+	})
+
+	if len(videos) > maxResults {
+		videos = videos[:maxResults]
+	}
+
+	return videos, nil
+}
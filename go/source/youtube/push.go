@@ -0,0 +1,57 @@
+package youtube
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+
+	"github.com/ryansiau/KeepUpdated/go/model"
+)
+
+// hubURL is the WebSub hub YouTube pushes the uploads of every channel to
+const hubURL = "https://pubsubhubbub.appspot.com/subscribe"
+
+var _ model.PushSource = (*Adapter)(nil)
+
+// Topic returns the feed of the channel's uploads. playlists have no feed that can be subscribed to.
+func (a *Adapter) Topic(ctx context.Context) (string, string, error) {
+	if a.playlistID != "" {
+		return "", "", fmt.Errorf("playlists can't be subscribed to through WebSub")
+	}
+
+	channelID := a.channelID
+	if a.handle != "" {
+		var err error
+		channelID, err = a.ResolveHandle(ctx, a.handle)
+		if err != nil {
+			return "", "", err
+		}
+	}
+
+	return "https://www.youtube.com/xml/feeds/videos.xml?channel_id=" + channelID, hubURL, nil
+}
+
+// ParsePush reads the ids of the videos in the pushed Atom feed, then fetches them like Fetch does
+func (a *Adapter) ParsePush(ctx context.Context, body []byte) ([]model.Content, error) {
+	var feed struct {
+		Entries []struct {
+			VideoID string `xml:"http://www.youtube.com/xml/schemas/2015 videoId"`
+		} `xml:"entry"`
+	}
+	if err := xml.Unmarshal(body, &feed); err != nil {
+		return nil, fmt.Errorf("failed to decode pushed feed: %w", err)
+	}
+
+	// deleted videos come as at:deleted-entry, which have no entry
+	var videoIDs []string
+	for _, entry := range feed.Entries {
+		if entry.VideoID != "" {
+			videoIDs = append(videoIDs, entry.VideoID)
+		}
+	}
+	if len(videoIDs) == 0 {
+		return nil, nil
+	}
+
+	return a.videoContents(ctx, videoIDs)
+}
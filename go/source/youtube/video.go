@@ -0,0 +1,160 @@
+package youtube
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"google.golang.org/api/youtube/v3"
+)
+
+// Kinds of videos, stored in Content.Metadata["kind"]
+const (
+	KindVideo    = "video"
+	KindShort    = "short"
+	KindLive     = "live"
+	KindUpcoming = "upcoming"
+	KindPremiere = "premiere"
+)
+
+var validKinds = []string{KindVideo, KindShort, KindLive, KindUpcoming, KindPremiere}
+
+// shortMaxDuration is the longest duration of a YouTube Short.
+// the API does not expose whether a video is a Short, so the duration is the best guess available.
+const shortMaxDuration = 3 * time.Minute
+
+// a premiere airs its upload after a countdown of one to ten minutes, while the recording of a livestream lasts
+// as long as it was on air. the API keeps the streaming details of both, so the countdown tells them apart.
+const (
+	premiereMinCountdown = time.Minute
+	premiereMaxCountdown = 10 * time.Minute
+)
+
+// FetchVideoDetails fetches the full details of the given videos, keeping the order of videoIDs.
+// videos that can't be found anymore (deleted, private) are omitted.
+func (a *Adapter) FetchVideoDetails(ctx context.Context, videoIDs []string) ([]*youtube.Video, error) {
+	if len(videoIDs) == 0 {
+		return nil, nil
+	}
+
+	found := map[string]*youtube.Video{}
+
+	// the API accepts up to 50 ids per call
+	for start := 0; start < len(videoIDs); start += 50 {
+		end := min(start+50, len(videoIDs))
+
+		response, err := a.client.Videos.List([]string{"snippet", "contentDetails", "liveStreamingDetails"}).
+			Id(videoIDs[start:end]...).
+			Context(ctx).
+			Do()
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch video details: %w", err)
+		}
+
+		for _, video := range response.Items {
+			found[video.Id] = video
+		}
+	}
+
+	var videos []*youtube.Video
+	for _, id := range videoIDs {
+		if video, ok := found[id]; ok {
+			videos = append(videos, video)
+		}
+	}
+
+	return videos, nil
+}
+
+// classify determines the kind of video based on its broadcast state and duration
+func classify(video *youtube.Video) string {
+	var broadcast string
+	if video.Snippet != nil {
+		broadcast = video.Snippet.LiveBroadcastContent
+	}
+
+	var duration time.Duration
+	if video.ContentDetails != nil {
+		duration, _ = parseISODuration(video.ContentDetails.Duration)
+	}
+
+	switch broadcast {
+	case "upcoming", "live":
+		// premieres are pre-recorded, so they already have a duration before and while airing
+		if duration > 0 {
+			return KindPremiere
+		}
+		if broadcast == "upcoming" {
+			return KindUpcoming
+		}
+		return KindLive
+	}
+
+	if isFinishedPremiere(video, duration) {
+		return KindPremiere
+	}
+
+	// finished livestreams keep their streaming details
+	if video.LiveStreamingDetails != nil && video.LiveStreamingDetails.ActualStartTime != "" {
+		return KindLive
+	}
+
+	if duration > 0 && duration <= shortMaxDuration {
+		return KindShort
+	}
+
+	return KindVideo
+}
+
+// isFinishedPremiere reports whether the video aired as a premiere, i.e. it was on air for its duration plus a countdown
+func isFinishedPremiere(video *youtube.Video, duration time.Duration) bool {
+	details := video.LiveStreamingDetails
+	if duration <= 0 || details == nil {
+		return false
+	}
+
+	start, err := time.Parse(time.RFC3339, details.ActualStartTime)
+	if err != nil {
+		return false
+	}
+	end, err := time.Parse(time.RFC3339, details.ActualEndTime)
+	if err != nil {
+		return false
+	}
+
+	countdown := end.Sub(start) - duration
+	return countdown >= premiereMinCountdown && countdown <= premiereMaxCountdown
+}
+
+// isUpcoming reports whether the video is scheduled but has not started airing yet
+func isUpcoming(video *youtube.Video) bool {
+	return video.Snippet != nil && video.Snippet.LiveBroadcastContent == "upcoming"
+}
+
+var isoDurationRegex = regexp.MustCompile(`^P(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// parseISODuration parses the ISO 8601 durations returned by the API, e.g. PT1H2M3S or P1DT2H
+func parseISODuration(s string) (time.Duration, error) {
+	match := isoDurationRegex.FindStringSubmatch(s)
+	if match == nil {
+		return 0, fmt.Errorf("unsupported duration: %s", s)
+	}
+
+	units := []time.Duration{24 * time.Hour, time.Hour, time.Minute, time.Second}
+
+	var duration time.Duration
+	for i, unit := range units {
+		if match[i+1] == "" {
+			continue
+		}
+		n, err := strconv.Atoi(match[i+1])
+		if err != nil {
+			return 0, err
+		}
+		duration += time.Duration(n) * unit
+	}
+
+	return duration, nil
+}
@@ -0,0 +1,174 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ryansiau/KeepUpdated/go/config"
+	"github.com/ryansiau/KeepUpdated/go/model"
+	"github.com/ryansiau/KeepUpdated/go/pkg/websub"
+)
+
+// hooksPathPrefix is the path of the webhooks, followed by the name of the workflow
+const hooksPathPrefix = "/hooks/"
+
+// maxHookSize caps the body of a webhook request
+const maxHookSize = 1 << 20
+
+// startServer listens on server.listen and serves the webhooks and the callbacks of the WebSub subscriptions
+func (w *Worker) startServer(ctx context.Context) (*http.Server, error) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(hooksPathPrefix, w.serveHook)
+
+	for _, workflow := range w.workflows {
+		if !workflow.WebSub {
+			continue
+		}
+
+		p, err := build(workflow)
+		if err != nil {
+			return nil, err
+		}
+
+		pushSource, ok := p.source.(model.PushSource)
+		if !ok {
+			return nil, fmt.Errorf("workflow %s: %s sources don't support websub", workflow.Name, workflow.Source.Type)
+		}
+
+		if w.websub == nil {
+			w.websub = websub.NewManager(w.server.PublicURL)
+		}
+		w.websub.Add(workflow.Name, pushSource.Topic, func(ctx context.Context, body []byte) error {
+			return w.push(ctx, workflow, body)
+		})
+	}
+
+	if w.websub != nil {
+		mux.Handle(websub.PathPrefix, w.websub)
+	}
+
+	// listen before returning, so a taken port fails the startup
+	listener, err := net.Listen("tcp", w.server.Listen)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", w.server.Listen, err)
+	}
+
+	server := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logrus.Errorf("HTTP server stopped: %v", err)
+		}
+	}()
+
+	// the hubs verify the subscriptions through the callbacks, which are reachable once the port is bound
+	if w.websub != nil {
+		go w.websub.Run(ctx)
+	}
+
+	logrus.Infof("Listening on %s", w.server.Listen)
+	return server, nil
+}
+
+// push processes the feed pushed by the hub of the workflow's source, like a poll would
+func (w *Worker) push(ctx context.Context, workflow config.Workflow, body []byte) error {
+	startTime := time.Now()
+
+	p, err := build(workflow)
+	if err != nil {
+		return err
+	}
+
+	contents, err := p.source.(model.PushSource).ParsePush(ctx, body)
+	if err != nil {
+		return err
+	}
+
+	logrus.WithField("workflow", workflow.Name).Infof("Received %d pushed contents from %s", len(contents), p.source.Name())
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.process(ctx, workflow, p, contents, startTime)
+}
+
+// serveHook processes the request to /hooks/<workflow> right away, through the workflow's filters and notifiers
+func (w *Worker) serveHook(rw http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		rw.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	name := strings.TrimPrefix(r.URL.Path, hooksPathPrefix)
+	idx := slices.IndexFunc(w.workflows, func(workflow config.Workflow) bool {
+		return workflow.Name == name
+	})
+	if idx < 0 {
+		http.NotFound(rw, r)
+		return
+	}
+	workflow := w.workflows[idx]
+
+	startTime := time.Now()
+
+	p, err := build(workflow)
+	if err != nil {
+		logrus.WithField("workflow", workflow.Name).Errorf("Failed to build workflow: %v", err)
+		rw.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	inboundSource, ok := p.source.(model.InboundSource)
+	if !ok {
+		http.NotFound(rw, r)
+		return
+	}
+
+	// a truncated payload would fail the signature or the parsing, reject it as too large instead
+	body, err := io.ReadAll(http.MaxBytesReader(rw, r.Body, maxHookSize))
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		rw.WriteHeader(http.StatusRequestEntityTooLarge)
+		return
+	}
+	if err != nil {
+		rw.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	contents, err := inboundSource.Receive(r.Context(), r.Header, body)
+	if errors.Is(err, model.ErrUnauthorized) {
+		logrus.WithField("workflow", workflow.Name).Warnf("Rejected webhook: %v", err)
+		rw.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	logrus.WithField("workflow", workflow.Name).Infof("Received %d contents from %s", len(contents), p.source.Name())
+
+	w.mu.Lock()
+	err = w.process(r.Context(), workflow, p, contents, startTime)
+	w.mu.Unlock()
+	if err != nil {
+		logrus.WithField("workflow", workflow.Name).Errorf("Failed to process webhook: %v", err)
+		rw.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	rw.WriteHeader(http.StatusAccepted)
+}
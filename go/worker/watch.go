@@ -0,0 +1,52 @@
+package worker
+
+import (
+	"container/heap"
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ryansiau/KeepUpdated/go/model"
+)
+
+// startWatches starts watching the sources that can tell when they change, the others are only polled
+func (w *Worker) startWatches(ctx context.Context) {
+	for _, workflow := range w.workflows {
+		p, err := build(workflow)
+		if err != nil {
+			// the execution reports the error
+			continue
+		}
+
+		watchingSource, ok := p.source.(model.WatchingSource)
+		if !ok {
+			continue
+		}
+
+		name := workflow.Name
+		err = watchingSource.Watch(ctx, func() {
+			select {
+			case w.triggers <- name:
+			case <-ctx.Done():
+			}
+		})
+		if err != nil {
+			logrus.WithField("workflow", name).Warnf("Failed to watch %s, falling back to polling: %v", p.source.Name(), err)
+			continue
+		}
+
+		logrus.WithField("workflow", name).Infof("Watching %s for changes", p.source.Name())
+	}
+}
+
+// trigger moves the next execution of the workflow to now
+func (w *Worker) trigger(name string) {
+	for idx, execution := range w.executions {
+		if execution.Workflow.Name == name {
+			execution.NextExecution = time.Now()
+			heap.Fix(&w.executions, idx)
+			return
+		}
+	}
+}
@@ -3,7 +3,9 @@ package worker
 import (
 	"container/heap"
 	"context"
+	"net/http"
 	"slices"
+	"sync"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -15,9 +17,13 @@ import (
 	"github.com/ryansiau/KeepUpdated/go/model"
 	"github.com/ryansiau/KeepUpdated/go/pkg/database"
 	graceful_shutdown "github.com/ryansiau/KeepUpdated/go/pkg/graceful-shutdown"
+	"github.com/ryansiau/KeepUpdated/go/pkg/websub"
 	workflow_heap "github.com/ryansiau/KeepUpdated/go/worker/workflow-heap"
 )
 
+// webSubPollInterval is how often a workflow whose hub pushes its updates is still polled, in case a push got lost
+const webSubPollInterval = 24 * time.Hour
+
 type Worker struct {
 	executions workflow_heap.WorkflowHeap
 	workflows  []config.Workflow
@@ -25,6 +31,25 @@ type Worker struct {
 	db *gorm.DB
 
 	gracefulShutdown graceful_shutdown.GracefulShutdown
+
+	// mu serializes the processing of polled and pushed contents
+	mu      sync.Mutex
+	retrier *retry.Retrier
+
+	server config.ServerConfig
+	websub *websub.Manager
+	// lastPolled is when each workflow with a WebSub subscription was last polled
+	lastPolled map[string]time.Time
+
+	// triggers receives the names of the workflows whose watched source changed
+	triggers chan string
+}
+
+// pipeline holds the implementors built from the configuration of a workflow
+type pipeline struct {
+	source    model.Source
+	filters   []model.Filter
+	notifiers []model.Notifier
 }
 
 func NewWorker(config *config.Config, gracefulShutdown graceful_shutdown.GracefulShutdown) (*Worker, error) {
@@ -56,6 +81,13 @@ func NewWorker(config *config.Config, gracefulShutdown graceful_shutdown.Gracefu
 		workflows:        config.Workflows,
 		db:               db,
 		gracefulShutdown: gracefulShutdown,
+		retrier: retry.New(
+			retry.Attempts(3),
+			retry.Delay(100*time.Millisecond),
+			retry.DelayType(retry.BackOffDelay)),
+		server:     config.Server,
+		lastPolled: map[string]time.Time{},
+		triggers:   make(chan string, len(config.Workflows)),
 	}, nil
 }
 
@@ -68,10 +100,22 @@ func (w *Worker) Run() error {
 		return err
 	}
 
-	retrier := retry.New(
-		retry.Attempts(3),
-		retry.Delay(100*time.Millisecond),
-		retry.DelayType(retry.BackOffDelay))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// the HTTP server receives the updates pushed by WebSub hubs and webhooks
+	var server *http.Server
+	if w.server.Listen != "" {
+		server, err = w.startServer(ctx)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = server.Shutdown(context.Background())
+		}()
+	}
+
+	w.startWatches(ctx)
 
 	for {
 		if w.gracefulShutdown.IsTerminated() {
@@ -87,205 +131,282 @@ func (w *Worker) Run() error {
 				heap.Pop(&w.executions)
 			}
 
-			// init usable variables
-			startTime := time.Now()
-			workflow := top.Workflow
-
-			var source model.Source
-			var filters []model.Filter
-			var notifiers []model.Notifier
-
-			// build configs into its own implementor
-			source, err = workflow.Source.Config.Build(workflow.Source.Name)
+			err = w.execute(ctx, top.Workflow)
 			if err != nil {
 				return err
 			}
 
-			for _, filter := range workflow.Filters {
-				f, err := filter.Config.Build()
-				if err != nil {
-					return err
-				}
-				filters = append(filters, f)
-			}
+			// assign a new schedule and re-register to the heap
+			top.NextExecution = time.Now().Add(top.Workflow.Interval)
+			heap.Push(&w.executions, top)
 
-			for _, notifier := range workflow.Notifiers {
-				n, err := notifier.Config.Build()
-				if err != nil {
-					return err
-				}
-				notifiers = append(notifiers, n)
+			if w.gracefulShutdown.IsTerminated() {
+				break
 			}
+		}
 
-			// get the latest PublishedAt recorded in the database
-			// TODO utilize this for data filtering instead of using id
-			var latestPublishedAt time.Time
-			dbResult := w.db.Model(&model.Content{}).
-				Select("published_at").
-				Where("source_id = ?", source.SourceID()).
-				Order("published_at DESC").
-				Limit(1).
-				Scan(&latestPublishedAt)
-			if dbResult.Error != nil {
-				return dbResult.Error
-			}
+		if w.gracefulShutdown.IsTerminated() {
+			break
+		}
 
-			// check if this is a new source
-			var isNewSource bool
-			if latestPublishedAt.IsZero() {
-				isNewSource = true
-			}
+		// how often the worker check if there's a workflow to run, a watched source wakes it up right away
+		select {
+		case name := <-w.triggers:
+			w.trigger(name)
+		case <-time.After(5 * time.Second):
+		}
+	}
 
-			// call into the sources
-			ctx := context.Background()
+	return nil
+}
 
-			contents, err := source.Fetch(ctx)
-			if err != nil {
-				return err
-			}
+// build builds the configs of the workflow into their own implementors
+func build(workflow config.Workflow) (*pipeline, error) {
+	source, err := workflow.Source.Config.Build(workflow.Source.Name)
+	if err != nil {
+		return nil, err
+	}
 
-			logrus.WithField("workflow", workflow.Name).Infof("Fetched %d contents from %s", len(contents), source.Name())
+	p := &pipeline{source: source}
 
-			// if the db is empty, fill the db with every update except the latest
-			if isNewSource {
-				dbResult = w.db.Create(contents[1:])
-				if dbResult.Error != nil {
-					return dbResult.Error
-				}
-			}
+	for _, filter := range workflow.Filters {
+		f, err := filter.Config.Build()
+		if err != nil {
+			return nil, err
+		}
+		p.filters = append(p.filters, f)
+	}
 
-			// fetch into db and filter out old updates
-			var contentIDs []string
-			for _, content := range contents {
-				contentIDs = append(contentIDs, content.ID)
-			}
+	for _, notifier := range workflow.Notifiers {
+		n, err := notifier.Config.Build()
+		if err != nil {
+			return nil, err
+		}
+		p.notifiers = append(p.notifiers, n)
+	}
 
-			// currently the most reliable way, by comparing the ids.
-			// however, comparison by PublishedAt is a good choice to consider
-			var trackedContents []model.Content
-			dbResult = w.db.
-				Where("source_id = ?", source.SourceID()).
-				Find(&trackedContents, contentIDs)
-			if dbResult.Error != nil {
-				return dbResult.Error
-			}
+	return p, nil
+}
 
-			trackedContentMaps := map[string]struct{}{}
-			for _, trackedContent := range trackedContents {
-				trackedContentMaps[trackedContent.ID] = struct{}{}
-			}
+// execute polls the source of the workflow and processes what it fetched
+func (w *Worker) execute(ctx context.Context, workflow config.Workflow) error {
+	// init usable variables
+	startTime := time.Now()
 
-			var newContents []model.Content
-			for _, content := range contents {
-				if _, ok := trackedContentMaps[content.ID]; !ok {
-					newContents = append(newContents, content)
-					logrus.Infof("Fetched new content from %s: %s", content.Platform, content.Title)
-				}
-			}
+	// while the hub pushes the updates, polling only makes sure none got lost
+	if w.websub != nil && w.websub.Active(workflow.Name) && time.Since(w.lastPolled[workflow.Name]) < webSubPollInterval {
+		logrus.WithField("workflow", workflow.Name).Debug("Updates are pushed through WebSub, skipping poll")
+		return nil
+	}
 
-			// apply filters
-			var filteredContents []model.Content
+	p, err := build(workflow)
+	if err != nil {
+		return err
+	}
+	source := p.source
 
-			for _, content := range newContents {
-				valid := true
+	// inbound sources are processed as their requests come in, there's nothing to poll
+	if _, ok := source.(model.InboundSource); ok {
+		return nil
+	}
 
-				for _, filter := range filters {
-					valid = valid && filter.Apply(content)
-				}
+	w.mu.Lock()
+	defer w.mu.Unlock()
 
-				if valid {
-					filteredContents = append(filteredContents, content)
-				} else {
-					logrus.Infof("Filtered out content from %s: %s", content.Platform, content.Title)
-				}
-			}
-			logrus.WithField("workflow", workflow.Name).Infof("Filtered %d contents", len(filteredContents))
-
-			// sort contents by PublishedAt
-			slices.SortFunc(filteredContents, func(a, b model.Content) int {
-				if a.PublishedAt.Before(b.PublishedAt) {
-					return -1
-				} else if a.PublishedAt.After(b.PublishedAt) {
-					return 1
-				}
-				return 0
-			})
+	// restore the cursor of sources that keep one between executions
+	statefulSource, isStateful := source.(model.StatefulSource)
+	if isStateful {
+		state, err := database.LoadSourceState(w.db, source.SourceID())
+		if err != nil {
+			return err
+		}
+		statefulSource.LoadState(state)
+	}
 
-			// call notifier
-			for _, notifier := range notifiers {
-				for _, content := range filteredContents {
-					err = retrier.Do(func() error {
-						return notifier.Send(ctx, content)
-					})
-					if err != nil {
-						return err
-					}
-				}
-			}
+	// call into the sources
+	contents, err := source.Fetch(ctx)
+	if err != nil {
+		return err
+	}
+	w.lastPolled[workflow.Name] = time.Now()
 
-			// TODO log request response history when sending notification. This serves as debugging log, but is it needed and is it secure?
-			//      logging req response, including url methods and auth seems scary as it'll store the complete url and auth header too
-			//      perhaps add an env or a new field in config to decide whether to log this?
-
-			// if notifier succeeds, the new updates MUST BE updated to the db. failing to update means they will be resented
-			//   in the next iteration. if the storing process errors or fails, find an alternative way to store this data.
-			//   perhaps just throw an error and stop the program?
-			if len(filteredContents) > 0 {
-				err = retrier.Do(func() error {
-					dbResult := w.db.Create(&filteredContents)
-					if dbResult.Error != nil {
-						return dbResult.Error
-					}
-					return nil
-				})
-				if err != nil {
-					return dbResult.Error
-				}
-			}
+	logrus.WithField("workflow", workflow.Name).Infof("Fetched %d contents from %s", len(contents), source.Name())
 
-			// log:
-			//   - which workflow has been called
-			//   - when
-			//   - the duration until the process finished
-			//   - data count information:
-			//       - new updates
-			//       - filtered out
-			//       - notified
-			//   - notification channels
-			var notificationChannelNames []string
-			for _, notifier := range notifiers {
-				notificationChannelNames = append(notificationChannelNames, notifier.Name())
-			}
+	err = w.process(ctx, workflow, p, contents, startTime)
+	if err != nil {
+		return err
+	}
 
-			logrus.WithFields(logrus.Fields{
-				"workflow":    workflow.Name,
-				"started_at":  startTime,
-				"finished_at": time.Now(),
-				"duration_ms": time.Since(startTime).Milliseconds(),
-				"summary": map[string]interface{}{
-					"new_updates":  len(newContents),
-					"filtered_out": len(newContents) - len(filteredContents),
-					"notified":     len(filteredContents),
-				},
-				"channels": notificationChannelNames,
-			}).Info("Finished processing workflow")
+	// the state is only stored once the contents are, so a failure doesn't skip any update
+	if isStateful {
+		err = w.retrier.Do(func() error {
+			return database.SaveSourceState(w.db, source.SourceID(), statefulSource.State())
+		})
+		if err != nil {
+			return err
+		}
+	}
 
-			// assign a new schedule and re-register to the heap
-			top.NextExecution = time.Now().Add(workflow.Interval)
-			heap.Push(&w.executions, top)
+	// a failed acknowledgement is only logged, the contents it covers are deduped on the next execution
+	if acknowledgingSource, ok := source.(model.AcknowledgingSource); ok && len(contents) > 0 {
+		err = acknowledgingSource.Acknowledge(ctx, contents)
+		if err != nil {
+			logrus.WithField("workflow", workflow.Name).Warnf("Failed to acknowledge the contents of %s: %v", source.Name(), err)
+		}
+	}
 
-			if w.gracefulShutdown.IsTerminated() {
-				break
+	return nil
+}
+
+// process dedupes the contents against the db, filters them, notifies the new ones and stores them.
+// polled and pushed contents both go through it, with w.mu held.
+func (w *Worker) process(ctx context.Context, workflow config.Workflow, p *pipeline, contents []model.Content, startTime time.Time) error {
+	source, filters, notifiers := p.source, p.filters, p.notifiers
+
+	// get the latest PublishedAt recorded in the database
+	// TODO utilize this for data filtering instead of using id
+	var latestPublishedAt time.Time
+	dbResult := w.db.Model(&model.Content{}).
+		Select("published_at").
+		Where("source_id = ?", source.SourceID()).
+		Order("published_at DESC").
+		Limit(1).
+		Scan(&latestPublishedAt)
+	if dbResult.Error != nil {
+		return dbResult.Error
+	}
+
+	// check if this is a new source
+	var isNewSource bool
+	if latestPublishedAt.IsZero() {
+		isNewSource = true
+	}
+
+	// if the db is empty, fill the db with every update except the latest.
+	// inbound sources only receive new updates, so they're all processed.
+	_, isInbound := source.(model.InboundSource)
+	if isNewSource && !isInbound && len(contents) > 1 {
+		dbResult = w.db.Create(contents[1:])
+		if dbResult.Error != nil {
+			return dbResult.Error
+		}
+	}
+
+	// fetch into db and filter out old updates
+	var contentIDs []string
+	for _, content := range contents {
+		contentIDs = append(contentIDs, content.ID)
+	}
+
+	// currently the most reliable way, by comparing the ids.
+	// however, comparison by PublishedAt is a good choice to consider
+	var trackedContents []model.Content
+	dbResult = w.db.
+		Where("source_id = ?", source.SourceID()).
+		Find(&trackedContents, contentIDs)
+	if dbResult.Error != nil {
+		return dbResult.Error
+	}
+
+	trackedContentMaps := map[string]struct{}{}
+	for _, trackedContent := range trackedContents {
+		trackedContentMaps[trackedContent.ID] = struct{}{}
+	}
+
+	var newContents []model.Content
+	for _, content := range contents {
+		if _, ok := trackedContentMaps[content.ID]; !ok {
+			newContents = append(newContents, content)
+			logrus.Infof("Fetched new content from %s: %s", content.Platform, content.Title)
+		}
+	}
+
+	// apply filters
+	var filteredContents []model.Content
+
+	for _, content := range newContents {
+		valid := true
+
+		for _, filter := range filters {
+			valid = valid && filter.Apply(content)
+		}
+
+		if valid {
+			filteredContents = append(filteredContents, content)
+		} else {
+			logrus.Infof("Filtered out content from %s: %s", content.Platform, content.Title)
+		}
+	}
+	logrus.WithField("workflow", workflow.Name).Infof("Filtered %d contents", len(filteredContents))
+
+	// sort contents by PublishedAt
+	slices.SortFunc(filteredContents, func(a, b model.Content) int {
+		if a.PublishedAt.Before(b.PublishedAt) {
+			return -1
+		} else if a.PublishedAt.After(b.PublishedAt) {
+			return 1
+		}
+		return 0
+	})
+
+	// call notifier
+	for _, notifier := range notifiers {
+		for _, content := range filteredContents {
+			err := w.retrier.Do(func() error {
+				return notifier.Send(ctx, content)
+			})
+			if err != nil {
+				return err
 			}
 		}
+	}
 
-		if w.gracefulShutdown.IsTerminated() {
-			break
+	// TODO log request response history when sending notification. This serves as debugging log, but is it needed and is it secure?
+	//      logging req response, including url methods and auth seems scary as it'll store the complete url and auth header too
+	//      perhaps add an env or a new field in config to decide whether to log this?
+
+	// if notifier succeeds, the new updates MUST BE updated to the db. failing to update means they will be resented
+	//   in the next iteration. if the storing process errors or fails, find an alternative way to store this data.
+	//   perhaps just throw an error and stop the program?
+	if len(filteredContents) > 0 {
+		err := w.retrier.Do(func() error {
+			dbResult := w.db.Create(&filteredContents)
+			if dbResult.Error != nil {
+				return dbResult.Error
+			}
+			return nil
+		})
+		if err != nil {
+			return err
 		}
+	}
 
-		// how often the worker check if there's a workflow to run
-		time.Sleep(5 * time.Second)
+	// log:
+	//   - which workflow has been called
+	//   - when
+	//   - the duration until the process finished
+	//   - data count information:
+	//       - new updates
+	//       - filtered out
+	//       - notified
+	//   - notification channels
+	var notificationChannelNames []string
+	for _, notifier := range notifiers {
+		notificationChannelNames = append(notificationChannelNames, notifier.Name())
 	}
 
+	logrus.WithFields(logrus.Fields{
+		"workflow":    workflow.Name,
+		"started_at":  startTime,
+		"finished_at": time.Now(),
+		"duration_ms": time.Since(startTime).Milliseconds(),
+		"summary": map[string]interface{}{
+			"new_updates":  len(newContents),
+			"filtered_out": len(newContents) - len(filteredContents),
+			"notified":     len(filteredContents),
+		},
+		"channels": notificationChannelNames,
+	}).Info("Finished processing workflow")
+
 	return nil
 }